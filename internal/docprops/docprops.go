@@ -0,0 +1,193 @@
+package docprops
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metadata holds the document properties of an OOXML package (.pptx, .xlsx),
+// read from docProps/core.xml and docProps/app.xml.
+type Metadata struct {
+	Title          string
+	Subject        string
+	Creator        string
+	LastModifiedBy string
+	Keywords       []string  // cp:keywords split on commas and semicolons
+	Created        time.Time // zero if not set
+	Modified       time.Time // zero if not set
+	Company        string
+	Application    string
+	Slides         int // slide count from app.xml, 0 if not set
+	Sheets         int // worksheet count from app.xml HeadingPairs, 0 if not set
+}
+
+// Read opens an OOXML file and reads its document properties.
+func Read(filePath string) (*Metadata, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open package: %w", err)
+	}
+	defer zr.Close()
+	return ReadZip(&zr.Reader)
+}
+
+// ReadZip reads document properties from an opened package.
+// Missing or malformed property parts leave the corresponding fields empty,
+// so a damaged docProps part never fails the conversion it decorates.
+func ReadZip(zr *zip.Reader) (*Metadata, error) {
+	meta := &Metadata{}
+
+	var core xmlCoreProperties
+	if data, ok := readPart(zr, "docProps/core.xml"); ok && xml.Unmarshal(data, &core) == nil {
+		meta.Title = strings.TrimSpace(core.Title)
+		meta.Subject = strings.TrimSpace(core.Subject)
+		meta.Creator = strings.TrimSpace(core.Creator)
+		meta.LastModifiedBy = strings.TrimSpace(core.LastModifiedBy)
+		meta.Keywords = splitKeywords(core.Keywords)
+		meta.Created = parseTime(core.Created)
+		meta.Modified = parseTime(core.Modified)
+	}
+
+	var app xmlAppProperties
+	if data, ok := readPart(zr, "docProps/app.xml"); ok && xml.Unmarshal(data, &app) == nil {
+		meta.Company = strings.TrimSpace(app.Company)
+		meta.Application = strings.TrimSpace(app.Application)
+		meta.Slides = app.Slides
+		meta.Sheets = app.worksheets()
+	}
+
+	return meta, nil
+}
+
+// Frontmatter renders the metadata as a YAML frontmatter block, or "" when
+// there is nothing to report.
+func (m *Metadata) Frontmatter() string {
+	var sb strings.Builder
+	str := func(key, value string) {
+		if value != "" {
+			sb.WriteString(key + ": " + strconv.Quote(value) + "\n")
+		}
+	}
+	date := func(key string, value time.Time) {
+		if !value.IsZero() {
+			sb.WriteString(key + ": " + value.Format(time.RFC3339) + "\n")
+		}
+	}
+	count := func(key string, value int) {
+		if value > 0 {
+			sb.WriteString(key + ": " + strconv.Itoa(value) + "\n")
+		}
+	}
+
+	str("title", m.Title)
+	str("subject", m.Subject)
+	str("author", m.Creator)
+	str("last_modified_by", m.LastModifiedBy)
+	if len(m.Keywords) > 0 {
+		sb.WriteString("keywords:\n")
+		for _, kw := range m.Keywords {
+			sb.WriteString("  - " + strconv.Quote(kw) + "\n")
+		}
+	}
+	date("created", m.Created)
+	date("modified", m.Modified)
+	str("company", m.Company)
+	count("slides", m.Slides)
+	count("sheets", m.Sheets)
+
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "---\n" + sb.String() + "---\n\n"
+}
+
+func readPart(zr *zip.Reader, name string) ([]byte, bool) {
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, false
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		return data, err == nil
+	}
+	return nil, false
+}
+
+func splitKeywords(s string) []string {
+	var keywords []string
+	for _, kw := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			keywords = append(keywords, kw)
+		}
+	}
+	return keywords
+}
+
+// w3cdtfLayouts are the W3CDTF profiles of ISO 8601 used by dcterms dates,
+// from most to least precise. RFC 3339 also accepts fractional seconds.
+var w3cdtfLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// parseTime reads a W3CDTF timestamp; malformed values count as unset.
+func parseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range w3cdtfLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// worksheets reads the "Worksheets" count from HeadingPairs, a vector of
+// alternating (group name, part count) variants.
+func (a xmlAppProperties) worksheets() int {
+	pairs := a.HeadingPairs
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if strings.EqualFold(pairs[i].Lpstr, "Worksheets") {
+			return pairs[i+1].I4
+		}
+	}
+	return 0
+}
+
+// --- XML structures ---
+
+type xmlCoreProperties struct {
+	XMLName        xml.Name `xml:"coreProperties"`
+	Title          string   `xml:"title"`
+	Subject        string   `xml:"subject"`
+	Creator        string   `xml:"creator"`
+	Keywords       string   `xml:"keywords"`
+	LastModifiedBy string   `xml:"lastModifiedBy"`
+	Created        string   `xml:"created"`
+	Modified       string   `xml:"modified"`
+}
+
+type xmlAppProperties struct {
+	XMLName      xml.Name     `xml:"Properties"`
+	Application  string       `xml:"Application"`
+	Company      string       `xml:"Company"`
+	Slides       int          `xml:"Slides"`
+	HeadingPairs []xmlVariant `xml:"HeadingPairs>vector>variant"`
+}
+
+type xmlVariant struct {
+	Lpstr string `xml:"lpstr"`
+	I4    int    `xml:"i4"`
+}
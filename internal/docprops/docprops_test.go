@@ -0,0 +1,145 @@
+package docprops
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const coreXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties"
+  xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/"
+  xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <dc:title>季度報告 "Q3"</dc:title>
+  <dc:subject>Sales</dc:subject>
+  <dc:creator>Alice</dc:creator>
+  <cp:keywords>sales; finance, 2024</cp:keywords>
+  <cp:lastModifiedBy>Bob</cp:lastModifiedBy>
+  <dcterms:created xsi:type="dcterms:W3CDTF">2024-01-02T03:04:05Z</dcterms:created>
+  <dcterms:modified xsi:type="dcterms:W3CDTF">2024-02-03T04:05:06Z</dcterms:modified>
+</cp:coreProperties>`
+
+const appXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"
+  xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes">
+  <Application>Microsoft Excel</Application>
+  <Company>Acme</Company>
+  <HeadingPairs><vt:vector size="4" baseType="variant">
+    <vt:variant><vt:lpstr>Worksheets</vt:lpstr></vt:variant>
+    <vt:variant><vt:i4>2</vt:i4></vt:variant>
+    <vt:variant><vt:lpstr>Named Ranges</vt:lpstr></vt:variant>
+    <vt:variant><vt:i4>1</vt:i4></vt:variant>
+  </vt:vector></HeadingPairs>
+</Properties>`
+
+func writePackage(t *testing.T, parts map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, content := range parts {
+		fw, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	assert.NoError(t, f.Close())
+	return path
+}
+
+func TestRead(t *testing.T) {
+	path := writePackage(t, map[string]string{
+		"docProps/core.xml": coreXML,
+		"docProps/app.xml":  appXML,
+	})
+
+	meta, err := Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &Metadata{
+		Title:          `季度報告 "Q3"`,
+		Subject:        "Sales",
+		Creator:        "Alice",
+		LastModifiedBy: "Bob",
+		Keywords:       []string{"sales", "finance", "2024"},
+		Created:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Modified:       time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC),
+		Company:        "Acme",
+		Application:    "Microsoft Excel",
+		Sheets:         2,
+	}, meta)
+
+	assert.Equal(t, `---
+title: "季度報告 \"Q3\""
+subject: "Sales"
+author: "Alice"
+last_modified_by: "Bob"
+keywords:
+  - "sales"
+  - "finance"
+  - "2024"
+created: 2024-01-02T03:04:05Z
+modified: 2024-02-03T04:05:06Z
+company: "Acme"
+sheets: 2
+---
+
+`, meta.Frontmatter())
+}
+
+func TestRead_MissingParts(t *testing.T) {
+	path := writePackage(t, map[string]string{"[Content_Types].xml": "<Types/>"})
+
+	meta, err := Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &Metadata{}, meta)
+	assert.Equal(t, "", meta.Frontmatter())
+
+	_, err = Read("nonexistent.xlsx")
+	assert.Error(t, err)
+}
+
+func TestRead_MalformedPart(t *testing.T) {
+	path := writePackage(t, map[string]string{
+		"docProps/core.xml": coreXML,
+		"docProps/app.xml":  `<Properties><Slides>many</Slides>`,
+	})
+
+	meta, err := Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", meta.Creator)
+	assert.Empty(t, meta.Application)
+
+	path = writePackage(t, map[string]string{
+		"docProps/core.xml": `<cp:coreProperties><dc:title>Broken`,
+		"docProps/app.xml":  appXML,
+	})
+
+	meta, err = Read(path)
+	assert.NoError(t, err)
+	assert.Empty(t, meta.Title)
+	assert.Equal(t, "Acme", meta.Company)
+}
+
+func TestParseTime(t *testing.T) {
+	plus8 := time.FixedZone("", 8*60*60)
+	for s, want := range map[string]time.Time{
+		"2024-05-01T10:00:30Z":   time.Date(2024, 5, 1, 10, 0, 30, 0, time.UTC),
+		"2024-05-01T10:00:30.5Z": time.Date(2024, 5, 1, 10, 0, 30, 500000000, time.UTC),
+		"2024-05-01T10:00+08:00": time.Date(2024, 5, 1, 10, 0, 0, 0, plus8),
+		"2024-05-01T10:00Z":      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		"2024-05-01":             time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		"2024-05":                time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		"2024":                   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		" 2024-05-01T10:00:00 ":  time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		"yesterday":              {},
+		"":                       {},
+	} {
+		assert.True(t, want.Equal(parseTime(s)), s)
+	}
+}
@@ -0,0 +1,133 @@
+package mdtable
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EscapeCell escapes Markdown-significant characters in a cell's text.
+// Table delimiters and line breaks are handled when the table is rendered.
+func EscapeCell(text string, mode EscapeMode) string {
+	if mode == EscapePreserve || text == "" {
+		return text
+	}
+	r := []rune(text)
+	at := func(i int) rune {
+		if i < 0 || i >= len(r) {
+			return ' '
+		}
+		return r[i]
+	}
+
+	var sb strings.Builder
+	start := 0
+	for start < len(r) && r[start] == ' ' {
+		start++
+	}
+	sb.WriteString(string(r[:start]))
+
+	// Block markers only matter at the start of the text
+	i := start
+	switch c := at(i); {
+	case c == '#' || c == '>':
+		sb.WriteRune('\\')
+	case (c == '-' || c == '+' || c == '*') && unicode.IsSpace(at(i+1)):
+		sb.WriteRune('\\')
+		sb.WriteRune(c)
+		i++
+	case unicode.IsDigit(c):
+		j := i
+		for j < len(r) && unicode.IsDigit(r[j]) {
+			j++
+		}
+		if (at(j) == '.' || at(j) == ')') && unicode.IsSpace(at(j+1)) {
+			sb.WriteString(string(r[i:j]) + "\\")
+			i = j
+		}
+	}
+
+	for ; i < len(r); i++ {
+		c := r[i]
+		switch c {
+		case '\\':
+			// Only a backslash before punctuation would act as an escape
+			if isASCIIPunct(at(i + 1)) {
+				sb.WriteRune('\\')
+			}
+		case '*', '~':
+			// Emphasis and strikethrough need a non-space neighbour
+			if !unicode.IsSpace(at(i-1)) || !unicode.IsSpace(at(i+1)) {
+				sb.WriteRune('\\')
+			}
+		case '_':
+			// Underscores inside a word never form emphasis
+			if !isWordRune(at(i-1)) || !isWordRune(at(i+1)) {
+				sb.WriteRune('\\')
+			}
+		case '`':
+			// A lone backtick cannot open a code span
+			if strings.Count(text, "`") > 1 {
+				sb.WriteRune('\\')
+			}
+		case '[':
+			if strings.ContainsRune(string(r[i+1:]), ']') {
+				sb.WriteRune('\\')
+			}
+		case '<':
+			// Tags, comments and autolinks start with a letter, "/", "!" or "?"
+			if next := at(i + 1); unicode.IsLetter(next) || next == '/' || next == '!' || next == '?' {
+				sb.WriteRune('\\')
+			}
+		case '&':
+			if isEntity(r[i+1:]) {
+				sb.WriteRune('\\')
+			}
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// CodeSpan wraps text in backticks as inline code, using a fence longer than
+// any run of backticks in the text.
+func CodeSpan(text string) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	if longest == 0 {
+		return "`" + text + "`"
+	}
+	fence := strings.Repeat("`", longest+1)
+	return fence + " " + text + " " + fence
+}
+
+func isASCIIPunct(c rune) bool {
+	return c <= unicode.MaxASCII && (unicode.IsPunct(c) || unicode.IsSymbol(c))
+}
+
+func isWordRune(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// isEntity reports whether text after "&" reads as an HTML entity such as "amp;" or "#39;".
+func isEntity(r []rune) bool {
+	n := 0
+	if len(r) > 0 && r[0] == '#' {
+		n = 1
+	}
+	for j := n; j < len(r) && j < 32; j++ {
+		switch {
+		case r[j] == ';':
+			return j > n
+		case !isWordRune(r[j]):
+			return false
+		}
+	}
+	return false
+}
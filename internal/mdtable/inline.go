@@ -0,0 +1,81 @@
+package mdtable
+
+import "strings"
+
+// Run is a piece of text with one set of inline formatting.
+type Run struct {
+	Text                 string
+	Bold, Italic, Strike bool
+	Code                 bool   // written as a code span
+	Link                 string // hyperlink target, empty if none
+}
+
+// RunsToMarkdown renders runs with inline Markdown formatting. Text outside
+// code spans is escaped with mode. Adjacent runs with the same formatting
+// are joined first, since documents often split text into runs for
+// formatting Markdown cannot show, such as color or spell check marks.
+func RunsToMarkdown(runs []Run, mode EscapeMode) string {
+	var merged []Run
+	for _, run := range runs {
+		if n := len(merged); n > 0 && sameFormat(merged[n-1], run) {
+			merged[n-1].Text += run.Text
+			continue
+		}
+		merged = append(merged, run)
+	}
+	var sb strings.Builder
+	for _, run := range merged {
+		sb.WriteString(runMarkdown(run, mode))
+	}
+	return sb.String()
+}
+
+// Link renders a Markdown link around text that is already Markdown,
+// escaping "]" so the text cannot close the link early.
+func Link(text, target string) string {
+	return link(strings.ReplaceAll(text, "]", "\\]"), target)
+}
+
+func link(text, target string) string {
+	if strings.ContainsAny(target, " ()") {
+		target = "<" + target + ">"
+	}
+	return "[" + text + "](" + target + ")"
+}
+
+func sameFormat(a, b Run) bool {
+	a.Text, b.Text = "", ""
+	return a == b
+}
+
+func runMarkdown(run Run, mode EscapeMode) string {
+	// Emphasis delimiters must touch non-space text, so keep surrounding spaces outside
+	text := strings.TrimSpace(run.Text)
+	if text == "" {
+		return run.Text
+	}
+	lead := run.Text[:strings.Index(run.Text, text)]
+	trail := run.Text[len(lead)+len(text):]
+
+	if run.Code {
+		text = CodeSpan(text)
+	} else {
+		text = EscapeCell(text, mode)
+		if run.Link != "" {
+			text = strings.ReplaceAll(text, "]", "\\]")
+		}
+	}
+	if run.Strike {
+		text = "~~" + text + "~~"
+	}
+	if run.Italic {
+		text = "*" + text + "*"
+	}
+	if run.Bold {
+		text = "**" + text + "**"
+	}
+	if run.Link != "" {
+		text = link(text, run.Link)
+	}
+	return lead + text + trail
+}
@@ -0,0 +1,50 @@
+// Package mdtable writes the Markdown shared by the converters: tables, cell
+// escaping and inline run formatting.
+package mdtable
+
+// MergedCellMode selects how merged cell ranges are rendered.
+type MergedCellMode int
+
+const (
+	// MergedCellsBlank keeps the value in the top-left cell and leaves the
+	// rest of the range empty, as the cells are stored in the file.
+	MergedCellsBlank MergedCellMode = iota
+	// MergedCellsRepeat copies the value into every cell of the range.
+	MergedCellsRepeat
+	// MergedCellsHTML renders the table as an HTML <table> with colspan/rowspan.
+	MergedCellsHTML
+)
+
+// EscapeMode selects how Markdown-significant characters in cell text are written.
+type EscapeMode int
+
+const (
+	// EscapeContextual backslash-escapes characters such as "*", "_", "<"
+	// or a leading "#" where they would otherwise change formatting, so
+	// cells read exactly as in the source.
+	EscapeContextual EscapeMode = iota
+	// EscapePreserve writes cell text unchanged, so Markdown typed into
+	// cells is rendered.
+	EscapePreserve
+)
+
+// TableStyle selects how the Markdown source of a table is laid out.
+type TableStyle int
+
+const (
+	// TableCompact writes each cell with a single space on both sides.
+	TableCompact TableStyle = iota
+	// TablePadded pads cells to their column's display width so the columns
+	// line up in a plain text editor.
+	TablePadded
+)
+
+// Alignment is a table column's alignment, written in the separator row.
+type Alignment int
+
+const (
+	AlignNone   Alignment = iota // "---"
+	AlignLeft                    // ":---"
+	AlignCenter                  // ":---:"
+	AlignRight                   // "---:"
+)
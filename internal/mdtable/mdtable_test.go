@@ -0,0 +1,113 @@
+package mdtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTable(t *testing.T) {
+	rows := [][]string{{"Item", "Qty"}, {"Bolt", "5"}}
+	assert.Equal(t, "| Item | Qty |\n| :--- | ---: |\n| Bolt | 5 |\n",
+		RenderTable(rows, nil, TableOptions{Aligns: []Alignment{AlignLeft, AlignRight}}))
+	assert.Equal(t, "| Item | Qty |\n| ---- | --- |\n| Bolt | 5   |\n",
+		RenderTable(rows, nil, TableOptions{Style: TablePadded}))
+}
+
+func TestRenderTable_MergedCells(t *testing.T) {
+	rows := [][]string{
+		{"Group", "", "Note"},
+		{"a", "b", "x<y & z"},
+		{"c"},
+	}
+	merges := []MergedRange{{Row: 0, Col: 0, Rows: 1, Cols: 2}, {Row: 1, Col: 2, Rows: 3, Cols: 1}}
+
+	assert.Equal(t, RenderTable(rows, nil, TableOptions{}), RenderTable(rows, merges, TableOptions{MergedCells: MergedCellsBlank}))
+
+	// Ranges running past ragged rows or the last row are clipped
+	assert.Equal(t, "| Group | Group | Note |\n"+
+		"| --- | --- | --- |\n"+
+		"| a | b | x<y & z |\n"+
+		"| c |  | x<y & z |\n", RenderTable(rows, merges, TableOptions{MergedCells: MergedCellsRepeat}))
+	assert.Len(t, rows[2], 1, "input rows must not be modified")
+
+	assert.Equal(t, "<table>\n"+
+		"  <tr><th colspan=\"2\">Group</th><th>Note</th></tr>\n"+
+		"  <tr><td>a</td><td>b</td><td rowspan=\"2\">x&lt;y &amp; z</td></tr>\n"+
+		"  <tr><td>c</td><td></td></tr>\n"+
+		"</table>\n", RenderTable(rows, merges, TableOptions{MergedCells: MergedCellsHTML}))
+}
+
+func TestEscapeCell(t *testing.T) {
+	for text, want := range map[string]string{
+		"plain text":      "plain text",
+		"5 * 3 = 15":      "5 * 3 = 15",
+		"*bold*":          "\\*bold\\*",
+		"snake_case_name": "snake_case_name",
+		"_private":        "\\_private",
+		"~~gone~~":        "\\~\\~gone\\~\\~",
+		"a < b":           "a < b",
+		"<b>x</b>":        "\\<b>x\\</b>",
+		"# 1 item":        "\\# 1 item",
+		"#1":              "\\#1",
+		"- item":          "\\- item",
+		"-5":              "-5",
+		"1. first":        "1\\. first",
+		"1.5":             "1.5",
+		"> quote":         "\\> quote",
+		"[link](x)":       "\\[link](x)",
+		"a [b":            "a [b",
+		"`code`":          "\\`code\\`",
+		"it`s":            "it`s",
+		"&amp; &x":        "\\&amp; &x",
+		`C:\dir`:          `C:\dir`,
+		`\*`:              `\\\*`,
+		"價格 *特價* 🎉":       "價格 \\*特價\\* 🎉",
+		"日本語_テキスト":        "日本語_テキスト",
+		"👍_ok":            "👍\\_ok",
+	} {
+		assert.Equal(t, want, EscapeCell(text, EscapeContextual), text)
+		assert.Equal(t, text, EscapeCell(text, EscapePreserve), text)
+	}
+}
+
+func TestCodeSpan(t *testing.T) {
+	assert.Equal(t, "`=SUM(A1:A3)`", CodeSpan("=SUM(A1:A3)"))
+	assert.Equal(t, "`` =\"`\" ``", CodeSpan("=\"`\""))
+	assert.Equal(t, "``` a``b ```", CodeSpan("a``b"))
+}
+
+func TestDisplayWidth(t *testing.T) {
+	for text, want := range map[string]int{
+		"":              0,
+		"abc":           3,
+		"中文":            4,
+		"ｶﾀｶﾅ":          4,
+		"ＡＢ":            4,
+		"한글":            4,
+		"🎉":             2,
+		"🏷\ufe0f":       2,
+		"🎉\ufe0f":       2,
+		"#\ufe0f\u20e3": 2,
+		"e\u0301":       1,
+		"a\u200db":      2,
+		"x<br>y\\|":     8,
+	} {
+		assert.Equal(t, want, displayWidth(text), text)
+	}
+}
+
+func TestRunsToMarkdown(t *testing.T) {
+	runs := []Run{
+		{Text: "Total ", Bold: true},
+		{Text: "due", Bold: true},
+		{Text: " *now* ", Italic: true},
+		{Text: "a`b", Code: true},
+		{Text: " see [1]", Link: "https://example.com/a b"},
+	}
+	assert.Equal(t, "**Total due** *\\*now\\** `` a`b `` [see \\[1\\]](<https://example.com/a b>)",
+		RunsToMarkdown(runs, EscapeContextual))
+	assert.Equal(t, "**Total due** **now** `` a`b `` [see [1\\]](<https://example.com/a b>)",
+		RunsToMarkdown(runs, EscapePreserve))
+	assert.Equal(t, "[**a** b\\]](https://example.com)", Link("**a** b]", "https://example.com"))
+}
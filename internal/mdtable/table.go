@@ -0,0 +1,239 @@
+package mdtable
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// MergedRange is a block of merged cells: the zero-based row and column of
+// its top-left cell and the number of rows and columns it covers.
+type MergedRange struct {
+	Row, Col   int
+	Rows, Cols int
+}
+
+// TableOptions controls how RenderTable writes a table.
+type TableOptions struct {
+	// MergedCells controls how merged ranges are rendered. Default leaves covered cells blank.
+	MergedCells MergedCellMode
+	// Style controls how the Markdown source is laid out. Default is compact.
+	Style TableStyle
+	// Aligns sets the alignment of the leading columns. Default leaves columns unaligned.
+	Aligns []Alignment
+}
+
+// RenderTable renders rows as a GFM table with the first row as header, or
+// as an HTML table when merges are rendered as HTML. Cells are written as
+// given, apart from table delimiters and line breaks.
+func RenderTable(rows [][]string, merges []MergedRange, opts TableOptions) string {
+	switch {
+	case len(merges) == 0 || opts.MergedCells == MergedCellsBlank:
+		return markdownTable(rows, opts.Aligns, opts.Style)
+	case opts.MergedCells == MergedCellsRepeat:
+		return markdownTable(FillMerged(rows, merges), opts.Aligns, opts.Style)
+	default:
+		return HTMLTable(rows, merges)
+	}
+}
+
+// FillMerged returns a copy of rows with each merged range's top-left value
+// repeated into every cell it covers.
+func FillMerged(rows [][]string, merges []MergedRange) [][]string {
+	filled := make([][]string, len(rows))
+	for i, row := range rows {
+		filled[i] = append([]string(nil), row...)
+	}
+	for _, m := range merges {
+		if m.Row >= len(filled) || m.Col >= len(filled[m.Row]) {
+			continue
+		}
+		value := filled[m.Row][m.Col]
+		for r := m.Row; r < m.Row+m.Rows && r < len(filled); r++ {
+			for len(filled[r]) < m.Col+m.Cols {
+				filled[r] = append(filled[r], "")
+			}
+			for c := m.Col; c < m.Col+m.Cols; c++ {
+				filled[r][c] = value
+			}
+		}
+	}
+	return filled
+}
+
+// HTMLTable renders rows as an HTML table, the first row as header cells,
+// with each cell HTML-escaped. GFM passes HTML blocks through unchanged, so
+// merged ranges keep their shape.
+func HTMLTable(rows [][]string, merges []MergedRange) string {
+	maxCols := 0
+	for _, row := range rows {
+		maxCols = max(maxCols, len(row))
+	}
+	if maxCols == 0 {
+		return ""
+	}
+
+	// Anchor cells carry the span; the other cells of a range are skipped
+	anchors := make(map[[2]int]MergedRange)
+	covered := make(map[[2]int]bool)
+	for _, m := range merges {
+		m.Rows = min(m.Rows, len(rows)-m.Row)
+		m.Cols = min(m.Cols, maxCols-m.Col)
+		if m.Rows < 1 || m.Cols < 1 {
+			continue
+		}
+		anchors[[2]int{m.Row, m.Col}] = m
+		for r := m.Row; r < m.Row+m.Rows; r++ {
+			for c := m.Col; c < m.Col+m.Cols; c++ {
+				if r != m.Row || c != m.Col {
+					covered[[2]int{r, c}] = true
+				}
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n")
+	for i, row := range rows {
+		tag := "td"
+		if i == 0 {
+			tag = "th"
+		}
+		sb.WriteString("  <tr>")
+		for j, cell := range padRow(row, maxCols) {
+			if covered[[2]int{i, j}] {
+				continue
+			}
+			attrs := ""
+			if m, ok := anchors[[2]int{i, j}]; ok {
+				if m.Cols > 1 {
+					attrs += fmt.Sprintf(` colspan="%d"`, m.Cols)
+				}
+				if m.Rows > 1 {
+					attrs += fmt.Sprintf(` rowspan="%d"`, m.Rows)
+				}
+			}
+			text := strings.ReplaceAll(html.EscapeString(cell), "\n", "<br>")
+			sb.WriteString(fmt.Sprintf("<%s%s>%s</%s>", tag, attrs, text, tag))
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</table>\n")
+	return sb.String()
+}
+
+// markdownTable renders rows as a GFM table with the first row as header.
+// Columns without an alignment in aligns use a plain "---" separator.
+func markdownTable(rows [][]string, aligns []Alignment, style TableStyle) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	// Determine max column count across all rows
+	maxCols := 0
+	for _, row := range rows {
+		if len(row) > maxCols {
+			maxCols = len(row)
+		}
+	}
+	if maxCols == 0 {
+		return ""
+	}
+
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = escapeCells(padRow(row, maxCols))
+	}
+	colAligns := make([]Alignment, maxCols)
+	copy(colAligns, aligns)
+
+	// Separator row, as wide as each column when padded
+	widths := make([]int, maxCols)
+	if style == TablePadded {
+		for j := range widths {
+			widths[j] = len(separator(colAligns[j], 0))
+		}
+		for _, row := range cells {
+			for j, cell := range row {
+				widths[j] = max(widths[j], displayWidth(cell))
+			}
+		}
+		for i, row := range cells {
+			for j, cell := range row {
+				cells[i][j] = padCell(cell, widths[j], colAligns[j])
+			}
+		}
+	}
+	seps := make([]string, maxCols)
+	for j := range seps {
+		seps[j] = separator(colAligns[j], widths[j])
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	writeRow(cells[0])
+	writeRow(seps)
+	for _, row := range cells[1:] {
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+// padRow ensures the row has exactly n columns, padding with empty strings.
+func padRow(row []string, n int) []string {
+	if len(row) >= n {
+		return row[:n]
+	}
+	padded := make([]string, n)
+	copy(padded, row)
+	return padded
+}
+
+// escapeCells escapes pipes and line breaks in cell values to avoid breaking Markdown tables.
+func escapeCells(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		out[i] = escapeTableCell(c)
+	}
+	return out
+}
+
+// escapeTableCell keeps a rendered cell on one table row: line breaks become
+// <br> and pipes are escaped.
+func escapeTableCell(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	text = strings.ReplaceAll(text, "\n", "<br>")
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+// separator returns a column's separator cell, padded with dashes to width
+// characters when it is shorter.
+func separator(a Alignment, width int) string {
+	left, right := "", ""
+	switch a {
+	case AlignLeft:
+		left = ":"
+	case AlignCenter:
+		left, right = ":", ":"
+	case AlignRight:
+		right = ":"
+	}
+	return left + strings.Repeat("-", max(width-len(left)-len(right), 3)) + right
+}
+
+// padCell pads a cell with spaces to width display columns on the side
+// opposite its alignment.
+func padCell(cell string, width int, a Alignment) string {
+	gap := max(width-displayWidth(cell), 0)
+	switch a {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + cell
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + cell + strings.Repeat(" ", gap-gap/2)
+	default:
+		return cell + strings.Repeat(" ", gap)
+	}
+}
@@ -0,0 +1,32 @@
+package mdtable
+
+import (
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// displayWidth returns how many terminal columns text takes: full-width and
+// wide East Asian characters count as 2, combining marks and other
+// zero-width characters as 0. A narrow character followed by the emoji
+// presentation selector U+FE0F, such as "🏷️", is drawn as a wide emoji.
+func displayWidth(text string) int {
+	n, last := 0, 0 // last is the width of the previous counted character
+	for _, r := range text {
+		if r == '\uFE0F' && last == 1 {
+			n, last = n+1, 2
+			continue
+		}
+		if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+			continue
+		}
+		switch width.LookupRune(r).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			last = 2
+		default:
+			last = 1
+		}
+		n += last
+	}
+	return n
+}
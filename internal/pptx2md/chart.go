@@ -0,0 +1,257 @@
+package pptx2md
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Chart holds the data cached in an embedded chart part (ppt/charts/chartN.xml).
+type Chart struct {
+	Type       string // plot kind such as "bar", "column", "line" or "pie"; combo charts join kinds with "+"
+	Title      string
+	Categories []string
+	Series     []ChartSeries
+}
+
+// ChartSeries is one data series of a chart; Values align with Chart.Categories.
+type ChartSeries struct {
+	Name   string
+	Values []string
+}
+
+// Caption names the chart type and title, e.g. "Chart (column): Quarterly Sales".
+func (c Chart) Caption() string {
+	caption := "Chart"
+	if c.Type != "" {
+		caption += " (" + c.Type + ")"
+	}
+	if c.Title != "" {
+		caption += ": " + c.Title
+	}
+	return caption
+}
+
+// Table lays the chart data out as a table: a header row, then one row per
+// category with a column per series.
+func (c Chart) Table() Table {
+	header := []string{"Category"}
+	n := len(c.Categories)
+	for i, ser := range c.Series {
+		name := ser.Name
+		if name == "" {
+			name = fmt.Sprintf("Series %d", i+1)
+		}
+		header = append(header, name)
+		if len(ser.Values) > n {
+			n = len(ser.Values)
+		}
+	}
+
+	rows := [][]string{header}
+	for i := 0; i < n; i++ {
+		row := []string{valueAt(c.Categories, i)}
+		for _, ser := range c.Series {
+			row = append(row, valueAt(ser.Values, i))
+		}
+		rows = append(rows, row)
+	}
+	return Table{Rows: rows}
+}
+
+func valueAt(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
+
+func extractChart(rID string, slide *Slide, ctx *slideContext, bounds Rect) {
+	target, ok := ctx.rels[rID]
+	if !ok {
+		return
+	}
+	// A broken chart part should not fail the whole slide
+	chart, err := parseChart(ctx.zr, resolveRelPath(ctx.dir, target))
+	if err != nil {
+		return
+	}
+	slide.Blocks = append(slide.Blocks, Block{Kind: BlockChart, Bounds: bounds, Chart: chart})
+}
+
+func parseChart(zr *zip.ReadCloser, chartPath string) (*Chart, error) {
+	data, err := readZipFile(zr, chartPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var cs xmlChartSpace
+	if err := xml.Unmarshal(data, &cs); err != nil {
+		return nil, fmt.Errorf("failed to parse chart XML: %w", err)
+	}
+
+	chart := &Chart{}
+	if cs.Chart.Title != nil && cs.Chart.Title.Tx != nil && cs.Chart.Title.Tx.Rich != nil {
+		var parts []string
+		for _, para := range cs.Chart.Title.Tx.Rich.Paragraphs {
+			if text := paragraphText(para); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		chart.Title = strings.Join(parts, " ")
+	}
+
+	var kinds []string
+	for _, plot := range cs.Chart.PlotArea.Plots {
+		if !strings.HasSuffix(plot.XMLName.Local, "Chart") || len(plot.Series) == 0 {
+			continue // axes, layout and shape properties share the plot area
+		}
+		kinds = append(kinds, plot.kind())
+		for _, ser := range plot.Series {
+			cat, val := ser.Cat, ser.Val
+			if val == nil {
+				cat, val = ser.XVal, ser.YVal // scatter and bubble charts
+			}
+			if len(chart.Categories) == 0 {
+				chart.Categories = cat.values()
+			}
+			chart.Series = append(chart.Series, ChartSeries{
+				Name:   ser.Tx.name(),
+				Values: val.values(),
+			})
+		}
+	}
+	chart.Type = strings.Join(kinds, "+")
+
+	return chart, nil
+}
+
+// kind names a plot element: "barChart" → "bar", or "column" for vertical bars.
+func (p xmlPlot) kind() string {
+	kind := strings.TrimSuffix(p.XMLName.Local, "Chart")
+	if p.BarDir != nil && p.BarDir.Val == "col" {
+		kind = strings.Replace(kind, "bar", "column", 1)
+	}
+	return kind
+}
+
+// maxMissingPoints bounds how many points a cache may leave out, so a corrupt
+// ptCount or point index cannot allocate an arbitrarily large series.
+const maxMissingPoints = 1024
+
+// values returns the cached points of a series reference, placed by point index.
+func (d *xmlSeriesData) values() []string {
+	if d == nil {
+		return nil
+	}
+	var cache *xmlChartCache
+	switch {
+	case d.StrRef != nil && d.StrRef.StrCache != nil:
+		cache = d.StrRef.StrCache
+	case d.NumRef != nil && d.NumRef.NumCache != nil:
+		cache = d.NumRef.NumCache
+	case d.StrLit != nil:
+		cache = d.StrLit
+	case d.NumLit != nil:
+		cache = d.NumLit
+	default:
+		return nil
+	}
+
+	n := 0
+	if cache.PtCount != nil {
+		n, _ = strconv.Atoi(cache.PtCount.Val)
+		n = max(n, 0)
+	}
+	for _, pt := range cache.Pts {
+		if pt.Idx >= n {
+			n = pt.Idx + 1
+		}
+	}
+	n = min(n, len(cache.Pts)+maxMissingPoints)
+	values := make([]string, n)
+	for _, pt := range cache.Pts {
+		if pt.Idx >= 0 && pt.Idx < n {
+			values[pt.Idx] = pt.V
+		}
+	}
+	return values
+}
+
+// name returns a series name from c:tx, which is either a reference or a literal c:v.
+func (d *xmlSeriesData) name() string {
+	if d == nil {
+		return ""
+	}
+	if d.V != "" {
+		return d.V
+	}
+	return strings.Join(d.values(), " ")
+}
+
+// --- XML structures ---
+
+type xmlChartSpace struct {
+	XMLName xml.Name `xml:"chartSpace"`
+	Chart   xmlChart `xml:"chart"`
+}
+
+type xmlChart struct {
+	Title    *xmlChartTitle `xml:"title"`
+	PlotArea xmlPlotArea    `xml:"plotArea"`
+}
+
+type xmlChartTitle struct {
+	Tx *xmlChartText `xml:"tx"`
+}
+
+type xmlChartText struct {
+	Rich *xmlTxBody `xml:"rich"`
+}
+
+type xmlPlotArea struct {
+	Plots []xmlPlot `xml:",any"`
+}
+
+type xmlPlot struct {
+	XMLName xml.Name
+	BarDir  *xmlChartVal `xml:"barDir"`
+	Series  []xmlSeries  `xml:"ser"`
+}
+
+type xmlSeries struct {
+	Tx   *xmlSeriesData `xml:"tx"`
+	Cat  *xmlSeriesData `xml:"cat"`
+	Val  *xmlSeriesData `xml:"val"`
+	XVal *xmlSeriesData `xml:"xVal"`
+	YVal *xmlSeriesData `xml:"yVal"`
+}
+
+type xmlSeriesData struct {
+	StrRef *xmlChartRefData `xml:"strRef"`
+	NumRef *xmlChartRefData `xml:"numRef"`
+	StrLit *xmlChartCache   `xml:"strLit"`
+	NumLit *xmlChartCache   `xml:"numLit"`
+	V      string           `xml:"v"`
+}
+
+type xmlChartRefData struct {
+	StrCache *xmlChartCache `xml:"strCache"`
+	NumCache *xmlChartCache `xml:"numCache"`
+}
+
+type xmlChartCache struct {
+	PtCount *xmlChartVal `xml:"ptCount"`
+	Pts     []xmlChartPt `xml:"pt"`
+}
+
+type xmlChartPt struct {
+	Idx int    `xml:"idx,attr"`
+	V   string `xml:"v"`
+}
+
+type xmlChartVal struct {
+	Val string `xml:"val,attr"`
+}
@@ -5,18 +5,42 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"ar-tools/internal/docprops"
+	"ar-tools/internal/mdtable"
 )
 
 // ConvertOptions holds configuration for pptx to markdown conversion.
 type ConvertOptions struct {
 	// ImageDir overrides the output image directory name. Empty uses default "{basename}_images".
 	ImageDir string
+	// ReadingOrder controls how shapes are ordered within a slide. Default keeps XML order.
+	ReadingOrder ReadingOrder
+	// HiddenSlides controls whether slides hidden in the slide show are converted.
+	HiddenSlides HiddenSlideMode
+	// Frontmatter prepends YAML frontmatter built from the document properties.
+	Frontmatter bool
+	// MergedCells controls how merged table cells are rendered. Default leaves covered cells blank.
+	MergedCells mdtable.MergedCellMode
+	// Escape controls how Markdown-significant characters in table cells are
+	// written. Default escapes them where they would change formatting.
+	Escape mdtable.EscapeMode
+	// TableStyle controls how the Markdown source of tables is laid out. Default is compact.
+	TableStyle mdtable.TableStyle
 }
 
 // ConvertResult holds the conversion output.
 type ConvertResult struct {
-	Markdown string
-	ImageDir string // actual image directory path (empty if no images)
+	Markdown       string
+	ImageDir       string           // actual image directory path (empty if no images)
+	MissingAltText []MissingAltText // images to fix before publishing
+}
+
+// MissingAltText identifies an image without alt text (p:cNvPr descr).
+type MissingAltText struct {
+	Slide     int    // 1-based slide index
+	Name      string // shape name, e.g. "Picture 3"
+	MediaPath string // e.g. "ppt/media/image1.png"
 }
 
 // Convert reads a .pptx file and returns Markdown with extracted images.
@@ -26,6 +50,7 @@ func Convert(filePath string, opts ConvertOptions) (*ConvertResult, error) {
 		return nil, err
 	}
 	defer pres.Close()
+	pres.Slides = pres.FilterSlides(opts.HiddenSlides)
 
 	baseName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
 	outDir := filepath.Dir(filePath)
@@ -45,7 +70,7 @@ func Convert(filePath string, opts ConvertOptions) (*ConvertResult, error) {
 	imageNames := make(map[string]int) // track duplicates
 
 	for _, slide := range pres.Slides {
-		for _, img := range slide.Images {
+		for _, img := range slide.Images() {
 			if img.MediaPath == "" {
 				continue
 			}
@@ -84,15 +109,41 @@ func Convert(filePath string, opts ConvertOptions) (*ConvertResult, error) {
 	}
 
 	// Build markdown
-	md := buildMarkdown(pres, imageDir)
+	md := buildMarkdown(pres, imageDir, opts)
+	if opts.Frontmatter {
+		meta, err := docprops.ReadZip(&pres.zip.Reader)
+		if err != nil {
+			return nil, err
+		}
+		md = meta.Frontmatter() + md
+	}
 
-	result := &ConvertResult{Markdown: md}
+	result := &ConvertResult{Markdown: md, MissingAltText: pres.MissingAltText()}
 	if len(images) > 0 {
 		result.ImageDir = imageDirFull
 	}
 	return result, nil
 }
 
+// MissingAltText lists the images that have no alt text, in slide order.
+// Captions found next to an image do not count, since they are not exposed
+// to screen readers in the deck itself.
+func (p *Presentation) MissingAltText() []MissingAltText {
+	var missing []MissingAltText
+	for _, slide := range p.Slides {
+		for _, img := range slide.Images() {
+			if img.Description == "" {
+				missing = append(missing, MissingAltText{
+					Slide:     slide.Index,
+					Name:      img.Name,
+					MediaPath: img.MediaPath,
+				})
+			}
+		}
+	}
+	return missing
+}
+
 // ConvertToString is a convenience function that returns only the Markdown string.
 func ConvertToString(filePath string, opts ConvertOptions) (string, error) {
 	result, err := Convert(filePath, opts)
@@ -102,14 +153,14 @@ func ConvertToString(filePath string, opts ConvertOptions) (string, error) {
 	return result.Markdown, nil
 }
 
-func buildMarkdown(pres *Presentation, imageDir string) string {
+func buildMarkdown(pres *Presentation, imageDir string, opts ConvertOptions) string {
 	var sb strings.Builder
 
 	// Build a mapping from media path to exported filename
 	imageFileMap := make(map[string]string)
 	imageNames := make(map[string]int)
 	for _, slide := range pres.Slides {
-		for _, img := range slide.Images {
+		for _, img := range slide.Images() {
 			if img.MediaPath == "" {
 				continue
 			}
@@ -129,34 +180,183 @@ func buildMarkdown(pres *Presentation, imageDir string) string {
 		}
 	}
 
+	currentSection := -1
 	for i, slide := range pres.Slides {
 		if i > 0 {
 			sb.WriteString("\n---\n\n")
 		}
 
+		// Section heading when a new PowerPoint section starts
+		if sec := pres.SectionOf(slide); sec >= 0 && sec != currentSection {
+			sb.WriteString(fmt.Sprintf("# %s\n\n", pres.Sections[sec].Name))
+			currentSection = sec
+		}
+
 		// Slide heading
 		if slide.Title != "" {
 			sb.WriteString(fmt.Sprintf("## %s\n\n", slide.Title))
 		} else {
 			sb.WriteString(fmt.Sprintf("## Slide %d\n\n", slide.Index))
 		}
-
-		// Body text
-		for _, body := range slide.Bodies {
-			sb.WriteString(body + "\n\n")
+		if slide.Hidden && opts.HiddenSlides == HiddenSlidesMark {
+			sb.WriteString("*(Hidden slide)*\n\n")
 		}
 
-		// Images
-		for _, img := range slide.Images {
-			if img.MediaPath == "" {
-				continue
+		// Shape contents in reading order
+		for _, block := range orderBlocks(slide.Blocks, opts.ReadingOrder, pres.SlideWidth) {
+			switch block.Kind {
+			case BlockText:
+				sb.WriteString(paragraphsToMarkdown(block.Paragraphs))
+			case BlockTable:
+				sb.WriteString(tableToMarkdown(*block.Table, opts))
+				sb.WriteString("\n")
+			case BlockChart:
+				sb.WriteString(chartToMarkdown(*block.Chart, opts))
+				sb.WriteString("\n")
+			case BlockDiagram:
+				sb.WriteString(paragraphsToMarkdown(block.Diagram.Paragraphs()))
+			case BlockImage:
+				if block.Image.MediaPath == "" {
+					continue
+				}
+				fileName := imageFileMap[block.Image.MediaPath]
+				// Use forward slash for markdown compatibility
+				imgPath := imageDir + "/" + fileName
+				sb.WriteString(imageToMarkdown(*block.Image, fileName, imgPath))
 			}
-			fileName := imageFileMap[img.MediaPath]
-			// Use forward slash for markdown compatibility
-			imgPath := imageDir + "/" + fileName
-			sb.WriteString(fmt.Sprintf("![%s](./%s)\n\n", fileName, imgPath))
+		}
+
+		// Speaker notes
+		if len(slide.Notes) > 0 {
+			sb.WriteString(notesToMarkdown(slide.Notes))
+			sb.WriteString("\n")
 		}
 	}
 
 	return strings.TrimRight(sb.String(), "\n") + "\n"
 }
+
+// imageToMarkdown renders an image link. Alt text falls back from the
+// description to a nearby caption to the file name; the title becomes the link title.
+func imageToMarkdown(img ImageRef, fileName, imgPath string) string {
+	alt := img.Description
+	if alt == "" {
+		alt = img.Caption
+	}
+	if alt == "" {
+		alt = fileName
+	}
+	alt = strings.NewReplacer("\n", " ", "[", "\\[", "]", "\\]").Replace(alt)
+
+	link := "./" + imgPath
+	if img.Title != "" {
+		link += ` "` + strings.ReplaceAll(img.Title, `"`, `\"`) + `"`
+	}
+	return fmt.Sprintf("![%s](%s)\n\n", alt, link)
+}
+
+// paragraphsToMarkdown renders body paragraphs, turning runs of bulleted
+// paragraphs into nested "-" / "1." lists indented by outline level.
+func paragraphsToMarkdown(paras []Paragraph) string {
+	var sb strings.Builder
+	var indents []int // content offset of the most recent item at each level
+	inList := false
+
+	for _, para := range paras {
+		if para.Bullet == BulletNone {
+			if inList {
+				sb.WriteString("\n")
+				inList = false
+			}
+			sb.WriteString(paragraphMarkdown(para) + "\n\n")
+			continue
+		}
+
+		if !inList {
+			indents = indents[:0]
+			inList = true
+		}
+
+		// Nested levels start at the content offset of their parent item;
+		// skipped levels fall back to a two-space step.
+		indent := 0
+		for lvl := 0; lvl < para.Level; lvl++ {
+			if lvl < len(indents) {
+				indent = indents[lvl]
+			} else {
+				indent += 2
+			}
+		}
+
+		marker := "-"
+		if para.Bullet == BulletNumber {
+			marker = "1."
+		}
+		sb.WriteString(strings.Repeat(" ", indent) + marker + " " + paragraphMarkdown(para) + "\n")
+
+		for len(indents) <= para.Level {
+			indents = append(indents, 0)
+		}
+		indents[para.Level] = indent + len(marker) + 1
+		indents = indents[:para.Level+1]
+	}
+	if inList {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// paragraphMarkdown renders a paragraph's runs with inline Markdown formatting.
+func paragraphMarkdown(para Paragraph) string {
+	if len(para.Runs) == 0 {
+		return para.Text
+	}
+	runs := make([]mdtable.Run, len(para.Runs))
+	for i, run := range para.Runs {
+		runs[i] = mdtable.Run{
+			Text: run.Text, Bold: run.Bold, Italic: run.Italic, Strike: run.Strike,
+			Code: run.Code, Link: run.Link,
+		}
+	}
+	return mdtable.RunsToMarkdown(runs, mdtable.EscapePreserve)
+}
+
+// notesToMarkdown renders speaker notes as a blockquote headed by "Notes".
+func notesToMarkdown(notes []string) string {
+	var sb strings.Builder
+	sb.WriteString("> **Notes**\n")
+	for _, para := range notes {
+		sb.WriteString(">\n")
+		sb.WriteString("> " + para + "\n")
+	}
+	return sb.String()
+}
+
+// chartToMarkdown renders a caption naming the chart type and title,
+// followed by the chart's cached data as a table.
+func chartToMarkdown(chart Chart, opts ConvertOptions) string {
+	return "*" + chart.Caption() + "*\n\n" + tableToMarkdown(chart.Table(), opts)
+}
+
+// tableToMarkdown renders a slide table as a GFM table with the shared
+// mdtable writer, which joins multi-paragraph cells with <br> so each row stays
+// on one line. Merged cells, escaping and layout follow opts; HTML tables
+// escape their cells as HTML instead.
+func tableToMarkdown(tbl Table, opts ConvertOptions) string {
+	if opts.MergedCells == mdtable.MergedCellsHTML && tbl.Spans != nil {
+		return mdtable.RenderTable(tbl.Rows, tbl.mergedRanges(), mdtable.TableOptions{MergedCells: opts.MergedCells})
+	}
+	rows := make([][]string, len(tbl.Rows))
+	for i, row := range tbl.Rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = mdtable.EscapeCell(cell, opts.Escape)
+		}
+		rows[i] = cells
+	}
+	return mdtable.RenderTable(rows, tbl.mergedRanges(), mdtable.TableOptions{
+		MergedCells: opts.MergedCells,
+		Style:       opts.TableStyle,
+	})
+}
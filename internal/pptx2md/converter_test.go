@@ -1,10 +1,15 @@
 package pptx2md
 
 import (
+	"archive/zip"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"ar-tools/internal/mdtable"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,25 +24,25 @@ func TestParse_SamplePptx(t *testing.T) {
 	s1 := pres.Slides[0]
 	assert.Equal(t, 1, s1.Index)
 	assert.Equal(t, "Welcome to ar-tools", s1.Title)
-	assert.Contains(t, s1.Bodies, "A developer toolkit")
+	assert.Contains(t, s1.Bodies(), "A developer toolkit")
 
 	// Slide 2: content + image
 	s2 := pres.Slides[1]
 	assert.Equal(t, 2, s2.Index)
 	assert.Equal(t, "Features Overview", s2.Title)
-	assert.Contains(t, s2.Bodies, "Excel to Markdown conversion")
-	assert.Contains(t, s2.Bodies, "PowerPoint to Markdown conversion")
-	assert.Len(t, s2.Images, 1)
-	assert.Equal(t, "ppt/media/image1.png", s2.Images[0].MediaPath)
+	assert.Contains(t, s2.Bodies(), "Excel to Markdown conversion")
+	assert.Contains(t, s2.Bodies(), "PowerPoint to Markdown conversion")
+	assert.Len(t, s2.Images(), 1)
+	assert.Equal(t, "ppt/media/image1.png", s2.Images()[0].MediaPath)
 
 	// Slide 3: table
 	s3 := pres.Slides[2]
 	assert.Equal(t, 3, s3.Index)
 	assert.Equal(t, "Comparison Table", s3.Title)
-	assert.Len(t, s3.Tables, 1)
-	assert.Len(t, s3.Tables[0].Rows, 4)
-	assert.Equal(t, []string{"Feature", "Status", "Notes"}, s3.Tables[0].Rows[0])
-	assert.Equal(t, []string{"XLSX to MD", "Done", "Multi-sheet support"}, s3.Tables[0].Rows[1])
+	assert.Len(t, s3.Tables(), 1)
+	assert.Len(t, s3.Tables()[0].Rows, 4)
+	assert.Equal(t, []string{"Feature", "Status", "Notes"}, s3.Tables()[0].Rows[0])
+	assert.Equal(t, []string{"XLSX to MD", "Done", "Multi-sheet support"}, s3.Tables()[0].Rows[1])
 }
 
 func TestParse_FileNotFound(t *testing.T) {
@@ -69,6 +74,11 @@ func TestConvert_SamplePptx(t *testing.T) {
 	assert.Contains(t, md, "Excel to Markdown conversion")
 	assert.Contains(t, md, "PowerPoint to Markdown conversion")
 
+	// Check table
+	assert.Contains(t, md, "| Feature | Status | Notes |")
+	assert.Contains(t, md, "| --- | --- | --- |")
+	assert.Contains(t, md, "| PPTX to PDF | In Progress | Tables and images |")
+
 	// Check image link
 	assert.Contains(t, md, "![image1.png](./sample_images/image1.png)")
 
@@ -96,6 +106,97 @@ func TestConvert_CustomImageDir(t *testing.T) {
 	assert.DirExists(t, filepath.Join(tmpDir, "my_pics"))
 }
 
+func TestTableToMarkdown(t *testing.T) {
+	tbl := Table{Rows: [][]string{
+		{"Key", "Value"},
+		{"a|b", "line1\nline2"},
+		{"only"},
+	}}
+
+	expected := "| Key | Value |\n" +
+		"| --- | --- |\n" +
+		"| a\\|b | line1<br>line2 |\n" +
+		"| only |  |\n"
+	assert.Equal(t, expected, tableToMarkdown(tbl, ConvertOptions{}))
+}
+
+func TestTableToMarkdown_Escape(t *testing.T) {
+	tbl := Table{Rows: [][]string{
+		{"名稱 🏷️", "說明"},
+		{"# 第一", "*重點*\n_注意_ 😀"},
+	}}
+
+	assert.Equal(t, "| 名稱 🏷️ | 說明 |\n| --- | --- |\n| \\# 第一 | \\*重點\\*<br>\\_注意\\_ 😀 |\n",
+		tableToMarkdown(tbl, ConvertOptions{}))
+	assert.Equal(t, "| 名稱 🏷️ | 說明 |\n| --- | --- |\n| # 第一 | *重點*<br>_注意_ 😀 |\n",
+		tableToMarkdown(tbl, ConvertOptions{Escape: mdtable.EscapePreserve}))
+}
+
+func TestTableToMarkdown_Padded(t *testing.T) {
+	tbl := Table{Rows: [][]string{
+		{"項目", "Owner"},
+		{"Launch 🚀", "陳"},
+	}}
+
+	assert.Equal(t, "| 項目      | Owner |\n"+
+		"| --------- | ----- |\n"+
+		"| Launch 🚀 | 陳    |\n", tableToMarkdown(tbl, ConvertOptions{TableStyle: mdtable.TablePadded}))
+}
+
+func TestParse_MergedTableCells(t *testing.T) {
+	cell := func(attrs, text string) string {
+		return `<a:tc` + attrs + `><a:txBody><a:bodyPr/><a:p><a:r><a:t>` + text + `</a:t></a:r></a:p></a:txBody></a:tc>`
+	}
+	file := buildPptx(t, map[string]string{
+		"ppt/slides/slide3.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+  <p:cSld><p:spTree>
+    <p:graphicFrame>
+      <p:nvGraphicFramePr><p:cNvPr id="2" name="Table 1"/><p:cNvGraphicFramePr/><p:nvPr/></p:nvGraphicFramePr>
+      <p:xfrm><a:off x="0" y="0"/><a:ext cx="6000000" cy="1000000"/></p:xfrm>
+      <a:graphic><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/table">
+        <a:tbl>
+          <a:tblGrid><a:gridCol w="1000000"/><a:gridCol w="2000000"/><a:gridCol w="3000000"/></a:tblGrid>
+          <a:tr h="370840">` + cell(``, "Region") + cell(` gridSpan="2"`, "Sales") + cell(` hMerge="1"`, "") + `</a:tr>
+          <a:tr h="370840">` + cell(` rowSpan="2"`, "North") + cell(``, "Q1") + cell(``, "10") + `</a:tr>
+          <a:tr h="370840">` + cell(` vMerge="1"`, "") + cell(``, "Q2") + cell(``, "12") + `</a:tr>
+        </a:tbl>
+      </a:graphicData></a:graphic>
+    </p:graphicFrame>
+  </p:spTree></p:cSld>
+</p:sld>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	tbl := pres.Slides[2].Tables()[0]
+	pres.Close()
+
+	assert.Equal(t, [][]string{{"Region", "Sales", ""}, {"North", "Q1", "10"}, {"", "Q2", "12"}}, tbl.Rows)
+	assert.Equal(t, []int64{1000000, 2000000, 3000000}, tbl.ColWidths)
+	assert.Equal(t, CellSpan{ColSpan: 2, RowSpan: 1}, tbl.Spans[0][1])
+	assert.Equal(t, CellSpan{ColSpan: 1, RowSpan: 1, Covered: true}, tbl.Spans[0][2])
+	assert.Equal(t, CellSpan{ColSpan: 1, RowSpan: 2}, tbl.Spans[1][0])
+	assert.Equal(t, CellSpan{ColSpan: 1, RowSpan: 1, Covered: true}, tbl.Spans[2][0])
+
+	repeat, err := ConvertToString(file, ConvertOptions{MergedCells: mdtable.MergedCellsRepeat})
+	assert.NoError(t, err)
+	assert.Contains(t, repeat, "| Region | Sales | Sales |\n| --- | --- | --- |\n| North | Q1 | 10 |\n| North | Q2 | 12 |\n")
+
+	htmlTable, err := ConvertToString(file, ConvertOptions{MergedCells: mdtable.MergedCellsHTML})
+	assert.NoError(t, err)
+	assert.Contains(t, htmlTable, "<table>\n"+
+		"  <tr><th>Region</th><th colspan=\"2\">Sales</th></tr>\n"+
+		"  <tr><td rowspan=\"2\">North</td><td>Q1</td><td>10</td></tr>\n"+
+		"  <tr><td>Q2</td><td>12</td></tr>\n"+
+		"</table>\n")
+
+	blank, err := ConvertToString(file, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, blank, "| Region | Sales |  |\n| --- | --- | --- |\n| North | Q1 | 10 |\n|  | Q2 | 12 |\n")
+}
+
 func TestReadMedia(t *testing.T) {
 	pres, err := Parse(filepath.Join("..", "..", "testdata", "sample.pptx"))
 	assert.NoError(t, err)
@@ -120,3 +221,738 @@ func TestReadMedia_NotFound(t *testing.T) {
 	_, err = pres.ReadMedia("ppt/media/nonexistent.png")
 	assert.Error(t, err)
 }
+
+func TestParse_Notes(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slides/_rels/slide1.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/>
+</Relationships>`,
+		"ppt/notesSlides/notesSlide1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:notes xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="2" name="Slide Image"/><p:cNvSpPr/><p:nvPr><p:ph type="sldImg"/></p:nvPr></p:nvSpPr>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="3" name="Notes"/><p:cNvSpPr/><p:nvPr><p:ph type="body" idx="1"/></p:nvPr></p:nvSpPr>
+      <p:txBody>
+        <a:p><a:r><a:t>Greet the audience</a:t></a:r></a:p>
+        <a:p><a:r><a:t>Mention the </a:t></a:r><a:r><a:t>roadmap</a:t></a:r></a:p>
+      </p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="4" name="Slide Number"/><p:cNvSpPr/><p:nvPr><p:ph type="sldNum" idx="5"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:fld type="slidenum"><a:t>1</a:t></a:fld></a:p></p:txBody>
+    </p:sp>
+  </p:spTree></p:cSld>
+</p:notes>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	defer pres.Close()
+
+	assert.Equal(t, []string{"Greet the audience", "Mention the roadmap"}, pres.Slides[0].Notes)
+	assert.Empty(t, pres.Slides[1].Notes)
+
+	result, err := Convert(file, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Markdown, "> **Notes**\n>\n> Greet the audience\n>\n> Mention the roadmap\n")
+}
+
+func TestParse_BulletInheritance(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slideMasters/slideMaster1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sldMaster xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:cSld><p:spTree>
+    <p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>
+    <p:grpSpPr/>
+  </p:spTree></p:cSld>
+  <p:txStyles>
+    <p:titleStyle><a:lvl1pPr><a:buNone/></a:lvl1pPr></p:titleStyle>
+    <p:bodyStyle>
+      <a:lvl1pPr><a:buChar char="•"/></a:lvl1pPr>
+      <a:lvl2pPr><a:buChar char="–"/></a:lvl2pPr>
+    </p:bodyStyle>
+    <p:otherStyle><a:lvl1pPr/></p:otherStyle>
+  </p:txStyles>
+</p:sldMaster>`,
+		"ppt/slides/slide2.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="2" name="Title 1"/><p:cNvSpPr/><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Agenda</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="3" name="Content 2"/><p:cNvSpPr/><p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr>
+      <p:txBody>
+        <a:p><a:r><a:t>Intro</a:t></a:r></a:p>
+        <a:p><a:pPr lvl="1"/><a:r><a:t>Background</a:t></a:r></a:p>
+        <a:p><a:pPr lvl="1"><a:buAutoNum type="arabicPeriod"/></a:pPr><a:r><a:t>Step one</a:t></a:r></a:p>
+        <a:p><a:pPr lvl="2"/><a:r><a:t>Detail</a:t></a:r></a:p>
+        <a:p><a:pPr><a:buNone/></a:pPr><a:r><a:t>Plain</a:t></a:r></a:p>
+      </p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="4" name="TextBox 3"/><p:cNvSpPr txBox="1"/><p:nvPr/></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Footnote</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+  </p:spTree></p:cSld>
+</p:sld>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	defer pres.Close()
+
+	s2 := pres.Slides[1]
+	assert.Equal(t, "Agenda", s2.Title)
+
+	var listing []Paragraph
+	for _, para := range s2.Paragraphs() {
+		para.Runs = nil
+		listing = append(listing, para)
+	}
+	assert.Equal(t, []Paragraph{
+		{Text: "Intro", Level: 0, Bullet: BulletChar},
+		{Text: "Background", Level: 1, Bullet: BulletChar},
+		{Text: "Step one", Level: 1, Bullet: BulletNumber},
+		{Text: "Detail", Level: 2, Bullet: BulletNone},
+		{Text: "Plain", Level: 0, Bullet: BulletNone},
+		{Text: "Footnote", Level: 0, Bullet: BulletNone},
+	}, listing)
+	assert.Equal(t, []string{"Intro", "Background", "Step one", "Detail", "Plain", "Footnote"}, s2.Bodies())
+}
+
+func TestParse_PlaceholderInheritance(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slideMasters/slideMaster1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sldMaster xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="2" name="Title 1"/><p:cNvSpPr/><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Click to edit Master title style</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="3" name="Text 2"/><p:cNvSpPr/><p:nvPr><p:ph type="body" idx="1"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Click to edit Master text styles</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+  </p:spTree></p:cSld>
+</p:sldMaster>`,
+		"ppt/slideLayouts/slideLayout1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sldLayout xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="2" name="Title 1"/><p:cNvSpPr/><p:nvPr><p:ph type="title" idx="10"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Quarterly Review</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="3" name="Content 2"/><p:cNvSpPr/><p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Click to edit Master text styles</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="4" name="Owner 3"/><p:cNvSpPr/><p:nvPr><p:ph type="body" idx="2" hasCustomPrompt="1"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Enter owner</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="5" name="Footer 4"/><p:cNvSpPr/><p:nvPr><p:ph type="ftr" idx="11"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Confidential</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+  </p:spTree></p:cSld>
+</p:sldLayout>`,
+		"ppt/slides/slide1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="2" name="Title 1"/><p:cNvSpPr/><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:endParaRPr/></a:p></p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="3" name="Content 2"/><p:cNvSpPr/><p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="4" name="Owner 3"/><p:cNvSpPr/><p:nvPr><p:ph type="body" idx="2"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p/></p:txBody>
+    </p:sp>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="5" name="Footer 4"/><p:cNvSpPr/><p:nvPr><p:ph type="ftr" idx="11"/></p:nvPr></p:nvSpPr>
+    </p:sp>
+  </p:spTree></p:cSld>
+</p:sld>`,
+		"ppt/slides/slide2.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="2" name="Title 1"/><p:cNvSpPr/><p:nvPr><p:ph idx="10"/></p:nvPr></p:nvSpPr>
+      <p:txBody><a:p><a:r><a:t>Roadmap</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+  </p:spTree></p:cSld>
+</p:sld>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	defer pres.Close()
+
+	// Template text fills the empty title and footer; prompt text is left out
+	assert.Equal(t, "Quarterly Review", pres.Slides[0].Title)
+	assert.Equal(t, []string{"Confidential"}, pres.Slides[0].Bodies())
+
+	// A placeholder given only by idx takes its title type from the layout
+	assert.Equal(t, "Roadmap", pres.Slides[1].Title)
+	assert.Empty(t, pres.Slides[1].Bodies())
+}
+
+func TestParagraphsToMarkdown(t *testing.T) {
+	paras := []Paragraph{
+		{Text: "Lead in"},
+		{Text: "Fruit", Bullet: BulletChar},
+		{Text: "Apple", Level: 1, Bullet: BulletNumber},
+		{Text: "Green", Level: 2, Bullet: BulletChar},
+		{Text: "Pear", Level: 1, Bullet: BulletNumber},
+		{Text: "Deep", Level: 3, Bullet: BulletChar},
+		{Text: "Veg", Bullet: BulletChar},
+		{Text: "Closing"},
+	}
+
+	expected := "Lead in\n\n" +
+		"- Fruit\n" +
+		"  1. Apple\n" +
+		"     - Green\n" +
+		"  1. Pear\n" +
+		"       - Deep\n" +
+		"- Veg\n" +
+		"\n" +
+		"Closing\n\n"
+	assert.Equal(t, expected, paragraphsToMarkdown(paras))
+}
+
+func TestParse_RunFormatting(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slides/_rels/slide1.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com/docs" TargetMode="External"/>
+</Relationships>`,
+		"ppt/slides/slide1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:cSld><p:spTree>
+    <p:sp>
+      <p:nvSpPr><p:cNvPr id="2" name="TextBox 1"/><p:cNvSpPr txBox="1"/><p:nvPr/></p:nvSpPr>
+      <p:txBody>
+        <a:p>
+          <a:r><a:rPr lang="en-US"/><a:t>Run </a:t></a:r>
+          <a:r><a:rPr b="1"/><a:t>make </a:t></a:r>
+          <a:r><a:rPr b="1"/><a:t>build</a:t></a:r>
+          <a:r><a:rPr/><a:t> or </a:t></a:r>
+          <a:r><a:rPr><a:latin typeface="Consolas"/></a:rPr><a:t>go test</a:t></a:r>
+          <a:r><a:rPr/><a:t>, see </a:t></a:r>
+          <a:r><a:rPr u="sng"><a:hlinkClick r:id="rId2"/></a:rPr><a:t>the docs</a:t></a:r>
+        </a:p>
+        <a:p>
+          <a:r><a:rPr i="1" strike="sngStrike"/><a:t>deprecated</a:t></a:r>
+          <a:r><a:rPr i="0"/><a:t> flag</a:t></a:r>
+        </a:p>
+      </p:txBody>
+    </p:sp>
+  </p:spTree></p:cSld>
+</p:sld>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	defer pres.Close()
+
+	paras := pres.Slides[0].Paragraphs()
+	assert.Len(t, paras, 2)
+	assert.Equal(t, "Run make build or go test, see the docs", paras[0].Text)
+	assert.Equal(t, Run{Text: "make ", Bold: true}, paras[0].Runs[1])
+	assert.Equal(t, Run{Text: "go test", Code: true}, paras[0].Runs[4])
+	assert.Equal(t, Run{Text: "the docs", Underline: true, Link: "https://example.com/docs"}, paras[0].Runs[6])
+	assert.Equal(t, Run{Text: "deprecated", Italic: true, Strike: true}, paras[1].Runs[0])
+
+	assert.Equal(t, "Run **make build** or `go test`, see [the docs](https://example.com/docs)", paragraphMarkdown(paras[0]))
+	assert.Equal(t, "*~~deprecated~~* flag", paragraphMarkdown(paras[1]))
+}
+
+func TestIsMonospaceFont(t *testing.T) {
+	for _, name := range []string{"Consolas", "Courier New", "Cascadia Code", "JetBrains Mono", "DejaVu Sans Mono", "Noto Sans Mono CJK SC"} {
+		assert.True(t, isMonospaceFont(name), name)
+	}
+	for _, name := range []string{"Calibri", "Monotype Corsiva", "Monotype Sorts", "Harmonia", "Mongolian Baiti"} {
+		assert.False(t, isMonospaceFont(name), name)
+	}
+}
+
+func TestParse_DocumentOrderWithNestedGroups(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slides/slide2.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:cSld><p:spTree>
+    <p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>
+    <p:grpSpPr/>
+    <p:pic>
+      <p:nvPicPr><p:cNvPr id="2" name="Picture 1"/><p:cNvPicPr/><p:nvPr/></p:nvPicPr>
+      <p:blipFill><a:blip r:embed="rId2"/></p:blipFill>
+    </p:pic>
+    <p:grpSp>
+      <p:nvGrpSpPr><p:cNvPr id="3" name="Group 2"/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>
+      <p:grpSpPr/>
+      <p:sp><p:txBody><a:p><a:r><a:t>Outer group text</a:t></a:r></a:p></p:txBody></p:sp>
+      <p:grpSp>
+        <p:nvGrpSpPr><p:cNvPr id="4" name="Group 3"/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>
+        <p:grpSpPr/>
+        <p:sp><p:txBody><a:p><a:r><a:t>Inner group text</a:t></a:r></a:p></p:txBody></p:sp>
+      </p:grpSp>
+    </p:grpSp>
+    <p:cxnSp><p:txBody><a:p><a:r><a:t>Connector label</a:t></a:r></a:p></p:txBody></p:cxnSp>
+    <p:sp><p:txBody><a:p><a:r><a:t>Closing text</a:t></a:r></a:p></p:txBody></p:sp>
+  </p:spTree></p:cSld>
+</p:sld>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	defer pres.Close()
+
+	s2 := pres.Slides[1]
+	assert.Equal(t, []string{"Outer group text", "Inner group text", "Connector label", "Closing text"}, s2.Bodies())
+
+	var kinds []BlockKind
+	for _, block := range s2.Blocks {
+		kinds = append(kinds, block.Kind)
+	}
+	assert.Equal(t, []BlockKind{BlockImage, BlockText, BlockText, BlockText, BlockText}, kinds)
+	assert.Equal(t, "ppt/media/image1.png", s2.Blocks[0].Image.MediaPath)
+	assert.Equal(t, "Inner group text", s2.Blocks[2].Paragraphs[0].Text)
+
+	result, err := Convert(file, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Markdown, "## Slide 2\n\n![image1.png](./test_images/image1.png)\n\nOuter group text\n\nInner group text\n\n")
+}
+
+func TestParse_ShapeBounds(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slides/slide2.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp>
+      <p:spPr><a:xfrm><a:off x="100" y="200"/><a:ext cx="300" cy="400"/></a:xfrm></p:spPr>
+      <p:txBody><a:p><a:r><a:t>Plain</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+    <p:grpSp>
+      <p:grpSpPr><a:xfrm><a:off x="1000" y="1000"/><a:ext cx="2000" cy="2000"/><a:chOff x="0" y="0"/><a:chExt cx="1000" cy="1000"/></a:xfrm></p:grpSpPr>
+      <p:sp>
+        <p:spPr><a:xfrm><a:off x="100" y="50"/><a:ext cx="200" cy="100"/></a:xfrm></p:spPr>
+        <p:txBody><a:p><a:r><a:t>Grouped</a:t></a:r></a:p></p:txBody>
+      </p:sp>
+    </p:grpSp>
+  </p:spTree></p:cSld>
+</p:sld>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	defer pres.Close()
+
+	blocks := pres.Slides[1].Blocks
+	assert.Len(t, blocks, 2)
+	assert.Equal(t, Rect{X: 100, Y: 200, W: 300, H: 400}, blocks[0].Bounds)
+	assert.Equal(t, Rect{X: 1200, Y: 1100, W: 400, H: 200}, blocks[1].Bounds)
+}
+
+func TestOrderBlocks(t *testing.T) {
+	text := func(s string, x, y, w int64) Block {
+		return Block{Kind: BlockText, Bounds: Rect{X: x, Y: y, W: w, H: 100}, Paragraphs: []Paragraph{{Text: s}}}
+	}
+	texts := func(blocks []Block) []string {
+		var out []string
+		for _, b := range blocks {
+			out = append(out, b.Paragraphs[0].Text)
+		}
+		return out
+	}
+
+	// Two columns under a full-width banner, placed in scrambled XML order
+	const width = 10000000
+	blocks := []Block{
+		text("right-2", 5500000, 3000000, 4000000),
+		text("left-2", 500000, 3000000, 4000000),
+		text("banner", 500000, 500000, 9000000),
+		text("right-1", 5500000, 1500000, 4000000),
+		text("left-1", 500000, 1550000, 4000000),
+		text("footer", 500000, 5000000, 9000000),
+	}
+
+	assert.Equal(t, []string{"right-2", "left-2", "banner", "right-1", "left-1", "footer"},
+		texts(orderBlocks(blocks, ReadingOrderXML, width)))
+	assert.Equal(t, []string{"banner", "left-1", "right-1", "left-2", "right-2", "footer"},
+		texts(orderBlocks(blocks, ReadingOrderTopToBottom, width)))
+	assert.Equal(t, []string{"banner", "left-1", "left-2", "right-1", "right-2", "footer"},
+		texts(orderBlocks(blocks, ReadingOrderColumns, width)))
+
+	// Without a right column, column mode reads top to bottom
+	single := []Block{text("b", 0, 2000000, 4000000), text("a", 0, 1000000, 4000000)}
+	assert.Equal(t, []string{"a", "b"}, texts(orderBlocks(single, ReadingOrderColumns, width)))
+}
+
+func TestParse_Chart(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slides/_rels/slide3.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart" Target="../charts/chart1.xml"/>
+</Relationships>`,
+		"ppt/slides/slide3.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:cSld><p:spTree>
+    <p:graphicFrame>
+      <p:xfrm><a:off x="0" y="0"/><a:ext cx="9144000" cy="3600000"/></p:xfrm>
+      <a:graphic>
+        <a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/chart">
+          <c:chart xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" r:id="rId2"/>
+        </a:graphicData>
+      </a:graphic>
+    </p:graphicFrame>
+  </p:spTree></p:cSld>
+</p:sld>`,
+		"ppt/charts/chart1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"
+  xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <c:chart>
+    <c:title><c:tx><c:rich><a:bodyPr/><a:p><a:r><a:t>Quarterly Sales</a:t></a:r></a:p></c:rich></c:tx></c:title>
+    <c:plotArea>
+      <c:layout/>
+      <c:barChart>
+        <c:barDir val="col"/>
+        <c:ser>
+          <c:tx><c:strRef><c:f>Sheet1!$B$1</c:f><c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>2024</c:v></c:pt></c:strCache></c:strRef></c:tx>
+          <c:cat><c:strRef><c:f>Sheet1!$A$2:$A$3</c:f><c:strCache><c:ptCount val="2"/>
+            <c:pt idx="0"><c:v>Q1</c:v></c:pt><c:pt idx="1"><c:v>Q2</c:v></c:pt>
+          </c:strCache></c:strRef></c:cat>
+          <c:val><c:numRef><c:f>Sheet1!$B$2:$B$3</c:f><c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="2"/>
+            <c:pt idx="0"><c:v>10</c:v></c:pt><c:pt idx="1"><c:v>12.5</c:v></c:pt>
+          </c:numCache></c:numRef></c:val>
+        </c:ser>
+        <c:ser>
+          <c:tx><c:v>2025</c:v></c:tx>
+          <c:val><c:numRef><c:numCache><c:ptCount val="2"/><c:pt idx="1"><c:v>14</c:v></c:pt></c:numCache></c:numRef></c:val>
+        </c:ser>
+      </c:barChart>
+      <c:catAx><c:axId val="1"/></c:catAx>
+    </c:plotArea>
+  </c:chart>
+</c:chartSpace>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	defer pres.Close()
+
+	s3 := pres.Slides[2]
+	assert.Len(t, s3.Charts(), 1)
+	chart := s3.Charts()[0]
+	assert.Equal(t, "column", chart.Type)
+	assert.Equal(t, "Quarterly Sales", chart.Title)
+	assert.Equal(t, []string{"Q1", "Q2"}, chart.Categories)
+	assert.Equal(t, []ChartSeries{
+		{Name: "2024", Values: []string{"10", "12.5"}},
+		{Name: "2025", Values: []string{"", "14"}},
+	}, chart.Series)
+
+	result, err := Convert(file, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Markdown, "*Chart (column): Quarterly Sales*\n\n"+
+		"| Category | 2024 | 2025 |\n"+
+		"| --- | --- | --- |\n"+
+		"| Q1 | 10 |  |\n"+
+		"| Q2 | 12.5 | 14 |\n")
+}
+
+func TestChartValues_HugePtCount(t *testing.T) {
+	data := &xmlSeriesData{NumLit: &xmlChartCache{
+		PtCount: &xmlChartVal{Val: "2000000000"},
+		Pts:     []xmlChartPt{{Idx: 0, V: "1"}, {Idx: 2, V: "3"}, {Idx: 1500000000, V: "9"}},
+	}}
+	values := data.values()
+	assert.Len(t, values, 3+maxMissingPoints)
+	assert.Equal(t, []string{"1", "", "3"}, values[:3])
+	assert.NotContains(t, values, "9")
+}
+
+func TestParse_SmartArt(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slides/_rels/slide3.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/diagramData" Target="../diagrams/data1.xml"/>
+</Relationships>`,
+		"ppt/slides/slide3.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:cSld><p:spTree>
+    <p:graphicFrame>
+      <a:graphic>
+        <a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/diagram">
+          <dgm:relIds xmlns:dgm="http://schemas.openxmlformats.org/drawingml/2006/diagram" r:dm="rId2" r:lo="rId3" r:qs="rId4" r:cs="rId5"/>
+        </a:graphicData>
+      </a:graphic>
+    </p:graphicFrame>
+  </p:spTree></p:cSld>
+</p:sld>`,
+		"ppt/diagrams/data1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<dgm:dataModel xmlns:dgm="http://schemas.openxmlformats.org/drawingml/2006/diagram"
+  xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <dgm:ptLst>
+    <dgm:pt modelId="0" type="doc"><dgm:t><a:p/></dgm:t></dgm:pt>
+    <dgm:pt modelId="1"><dgm:t><a:p><a:r><a:t>CEO</a:t></a:r></a:p></dgm:t></dgm:pt>
+    <dgm:pt modelId="2"><dgm:t><a:p><a:r><a:t>CTO</a:t></a:r></a:p></dgm:t></dgm:pt>
+    <dgm:pt modelId="3"><dgm:t><a:p><a:r><a:t>CFO</a:t></a:r></a:p></dgm:t></dgm:pt>
+    <dgm:pt modelId="4" type="asst"><dgm:t><a:p><a:r><a:t>Assistant</a:t></a:r></a:p></dgm:t></dgm:pt>
+    <dgm:pt modelId="9" type="parTrans"><dgm:t><a:p/></dgm:t></dgm:pt>
+    <dgm:pt modelId="10" type="pres"/>
+  </dgm:ptLst>
+  <dgm:cxnLst>
+    <dgm:cxn modelId="20" srcId="0" destId="1" srcOrd="0" destOrd="0"/>
+    <dgm:cxn modelId="21" srcId="1" destId="3" srcOrd="2" destOrd="0"/>
+    <dgm:cxn modelId="22" srcId="1" destId="2" srcOrd="1" destOrd="0"/>
+    <dgm:cxn modelId="23" srcId="1" destId="4" srcOrd="0" destOrd="0"/>
+    <dgm:cxn modelId="24" type="presOf" srcId="1" destId="10" srcOrd="0" destOrd="0"/>
+  </dgm:cxnLst>
+</dgm:dataModel>`,
+	})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	defer pres.Close()
+
+	s3 := pres.Slides[2]
+	assert.Len(t, s3.Diagrams(), 1)
+	assert.Equal(t, []DiagramNode{{
+		Text: "CEO",
+		Children: []DiagramNode{
+			{Text: "Assistant"},
+			{Text: "CTO"},
+			{Text: "CFO"},
+		},
+	}}, s3.Diagrams()[0].Nodes)
+
+	result, err := Convert(file, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Markdown, "- CEO\n  - Assistant\n  - CTO\n  - CFO\n")
+}
+
+func TestParse_ImageAltText(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"ppt/slides/_rels/slide2.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="../media/image1.png"/>
+</Relationships>`,
+		"ppt/slides/slide2.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:cSld><p:spTree>
+    <p:pic>
+      <p:nvPicPr><p:cNvPr id="2" name="Logo" descr="Company [logo]" title="Our &quot;brand&quot;"/><p:cNvPicPr/><p:nvPr/></p:nvPicPr>
+      <p:blipFill><a:blip r:embed="rId2"/></p:blipFill>
+      <p:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="1000" cy="1000"/></a:xfrm></p:spPr>
+    </p:pic>
+    <p:pic>
+      <p:nvPicPr><p:cNvPr id="3" name="Picture 2"/><p:cNvPicPr/><p:nvPr/></p:nvPicPr>
+      <p:blipFill><a:blip r:embed="rId2"/></p:blipFill>
+      <p:spPr><a:xfrm><a:off x="5000" y="0"/><a:ext cx="1000" cy="1000"/></a:xfrm></p:spPr>
+    </p:pic>
+    <p:sp>
+      <p:spPr><a:xfrm><a:off x="5000" y="1100"/><a:ext cx="1000" cy="200"/></a:xfrm></p:spPr>
+      <p:txBody><a:p><a:r><a:t>Figure 1: Architecture</a:t></a:r></a:p></p:txBody>
+    </p:sp>
+    <p:pic>
+      <p:nvPicPr><p:cNvPr id="4" name="Picture 3"/><p:cNvPicPr/><p:nvPr/></p:nvPicPr>
+      <p:blipFill><a:blip r:embed="rId2"/></p:blipFill>
+    </p:pic>
+  </p:spTree></p:cSld>
+</p:sld>`,
+	})
+
+	result, err := Convert(file, ConvertOptions{})
+	assert.NoError(t, err)
+
+	md := result.Markdown
+	assert.Contains(t, md, `![Company \[logo\]](./test_images/image1.png "Our \"brand\"")`)
+	assert.Contains(t, md, "![Figure 1: Architecture](./test_images/image1.png)")
+	assert.Equal(t, 1, strings.Count(md, "Figure 1: Architecture"), "caption should only be written as alt text")
+	assert.Contains(t, md, "![image1.png](./test_images/image1.png)")
+
+	assert.Equal(t, []MissingAltText{
+		{Slide: 2, Name: "Picture 2", MediaPath: "ppt/media/image1.png"},
+		{Slide: 2, Name: "Picture 3", MediaPath: "ppt/media/image1.png"},
+	}, result.MissingAltText)
+}
+
+func TestConvert_HiddenSlides(t *testing.T) {
+	sample, err := zip.OpenReader(filepath.Join("..", "..", "testdata", "sample.pptx"))
+	assert.NoError(t, err)
+	var slide2 []byte
+	for _, f := range sample.File {
+		if f.Name == "ppt/slides/slide2.xml" {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+			slide2, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	sample.Close()
+
+	hidden := strings.Replace(string(slide2), "<p:sld ", `<p:sld show="0" `, 1)
+	file := buildPptx(t, map[string]string{"ppt/slides/slide2.xml": hidden})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	assert.False(t, pres.Slides[0].Hidden)
+	assert.True(t, pres.Slides[1].Hidden)
+	pres.Close()
+
+	included, err := ConvertToString(file, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, included, "## Features Overview\n\nExcel")
+
+	excluded, err := Convert(file, ConvertOptions{HiddenSlides: HiddenSlidesExclude})
+	assert.NoError(t, err)
+	assert.NotContains(t, excluded.Markdown, "Features Overview")
+	assert.Equal(t, 2, strings.Count(excluded.Markdown, "## "))
+	assert.Empty(t, excluded.ImageDir)
+
+	marked, err := ConvertToString(file, ConvertOptions{HiddenSlides: HiddenSlidesMark})
+	assert.NoError(t, err)
+	assert.Contains(t, marked, "## Features Overview\n\n*(Hidden slide)*\n\nExcel")
+}
+
+func TestParse_Sections(t *testing.T) {
+	presXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:presentation xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+  xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:sldMasterIdLst><p:sldMasterId r:id="rId4"/></p:sldMasterIdLst>
+  <p:sldIdLst>
+    <p:sldId id="256" r:id="rId1"/>
+    <p:sldId id="257" r:id="rId2"/>
+    <p:sldId id="258" r:id="rId3"/>
+  </p:sldIdLst>
+  <p:extLst>
+    <p:ext uri="{521415D9-36F7-43E2-AB2F-B90AF26B5E84}">
+      <p14:sectionLst xmlns:p14="http://schemas.microsoft.com/office/powerpoint/2010/main">
+        <p14:section name="Introduction" id="{A1}">
+          <p14:sldIdLst><p14:sldId id="256"/></p14:sldIdLst>
+        </p14:section>
+        <p14:section name="Details" id="{A2}">
+          <p14:sldIdLst><p14:sldId id="257"/><p14:sldId id="258"/></p14:sldIdLst>
+        </p14:section>
+      </p14:sectionLst>
+    </p:ext>
+  </p:extLst>
+</p:presentation>`
+	file := buildPptx(t, map[string]string{"ppt/presentation.xml": presXML})
+
+	pres, err := Parse(file)
+	assert.NoError(t, err)
+	assert.Equal(t, []Section{
+		{Name: "Introduction", SlideIDs: []int{256}},
+		{Name: "Details", SlideIDs: []int{257, 258}},
+	}, pres.Sections)
+	assert.Equal(t, 257, pres.Slides[1].ID)
+	assert.Equal(t, 0, pres.SectionOf(pres.Slides[0]))
+	assert.Equal(t, 1, pres.SectionOf(pres.Slides[2]))
+	pres.Close()
+
+	md, err := ConvertToString(file, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(md, "# Introduction\n\n## Welcome to ar-tools\n\n"))
+	assert.Contains(t, md, "\n---\n\n# Details\n\n## Features Overview\n\n")
+	assert.Contains(t, md, "\n---\n\n## Comparison Table\n\n")
+	assert.Equal(t, 2, strings.Count("\n"+md, "\n# "))
+}
+
+func TestConvert_Frontmatter(t *testing.T) {
+	file := buildPptx(t, map[string]string{
+		"docProps/core.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties"
+  xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <dc:title>Product Tour</dc:title>
+  <dc:creator>Alice</dc:creator>
+</cp:coreProperties>`,
+		"docProps/app.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties">
+  <Slides>3</Slides>
+</Properties>`,
+	})
+
+	md, err := ConvertToString(file, ConvertOptions{Frontmatter: true})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(md, "---\ntitle: \"Product Tour\"\nauthor: \"Alice\"\nslides: 3\n---\n\n## Welcome to ar-tools\n"))
+
+	md, err = ConvertToString(file, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(md, "## Welcome to ar-tools\n"))
+}
+
+// buildPptx copies testdata/sample.pptx into a temp dir, replacing or adding
+// the given parts, and returns the new file path.
+func buildPptx(t *testing.T, parts map[string]string) string {
+	t.Helper()
+
+	src, err := zip.OpenReader(filepath.Join("..", "..", "testdata", "sample.pptx"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer src.Close()
+
+	outPath := filepath.Join(t.TempDir(), "test.pptx")
+	out, err := os.Create(outPath)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	for _, f := range src.File {
+		if _, ok := parts[f.Name]; ok {
+			continue
+		}
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		w, err := zw.Create(f.Name)
+		assert.NoError(t, err)
+		_, err = io.Copy(w, rc)
+		assert.NoError(t, err)
+		rc.Close()
+	}
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+
+	return outPath
+}
@@ -0,0 +1,147 @@
+package pptx2md
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Diagram holds the text hierarchy of a SmartArt graphic, read from its
+// data model part (ppt/diagrams/dataN.xml).
+type Diagram struct {
+	Nodes []DiagramNode // top-level nodes
+}
+
+// DiagramNode is one SmartArt node with its child nodes.
+type DiagramNode struct {
+	Text     string
+	Children []DiagramNode
+}
+
+// Paragraphs flattens the hierarchy into bulleted paragraphs whose level is the node depth.
+func (d Diagram) Paragraphs() []Paragraph {
+	var paras []Paragraph
+	var walk func(nodes []DiagramNode, level int)
+	walk = func(nodes []DiagramNode, level int) {
+		for _, node := range nodes {
+			paras = append(paras, Paragraph{Text: node.Text, Level: level, Bullet: BulletChar})
+			walk(node.Children, level+1)
+		}
+	}
+	walk(d.Nodes, 0)
+	return paras
+}
+
+func extractDiagram(rID string, slide *Slide, ctx *slideContext, bounds Rect) {
+	target, ok := ctx.rels[rID]
+	if !ok {
+		return
+	}
+	// A broken diagram part should not fail the whole slide
+	diagram, err := parseDiagram(ctx.zr, resolveRelPath(ctx.dir, target))
+	if err != nil || len(diagram.Nodes) == 0 {
+		return
+	}
+	slide.Blocks = append(slide.Blocks, Block{Kind: BlockDiagram, Bounds: bounds, Diagram: diagram})
+}
+
+// parseDiagram rebuilds the node tree from the data model's points and
+// their parent-of connections, ordered by srcOrd.
+func parseDiagram(zr *zip.ReadCloser, dataPath string) (*Diagram, error) {
+	data, err := readZipFile(zr, dataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var dm xmlDataModel
+	if err := xml.Unmarshal(data, &dm); err != nil {
+		return nil, fmt.Errorf("failed to parse diagram data XML: %w", err)
+	}
+
+	texts := make(map[string]string)
+	var rootID string
+	for _, pt := range dm.Points {
+		switch pt.Type {
+		case "", "node", "asst":
+			texts[pt.ModelID] = pt.text()
+		case "doc":
+			rootID = pt.ModelID
+		}
+	}
+
+	children := make(map[string][]xmlDgmCxn)
+	for _, cxn := range dm.Cxns {
+		if cxn.Type != "" && cxn.Type != "parOf" {
+			continue
+		}
+		children[cxn.SrcID] = append(children[cxn.SrcID], cxn)
+	}
+	for id := range children {
+		sort.SliceStable(children[id], func(i, j int) bool {
+			return children[id][i].SrcOrd < children[id][j].SrcOrd
+		})
+	}
+
+	visited := make(map[string]bool)
+	var build func(id string) []DiagramNode
+	build = func(id string) []DiagramNode {
+		var nodes []DiagramNode
+		for _, cxn := range children[id] {
+			text, ok := texts[cxn.DestID]
+			if !ok || visited[cxn.DestID] {
+				continue
+			}
+			visited[cxn.DestID] = true
+			kids := build(cxn.DestID)
+			if text == "" {
+				// Empty placeholders keep their children at the same depth
+				nodes = append(nodes, kids...)
+				continue
+			}
+			nodes = append(nodes, DiagramNode{Text: text, Children: kids})
+		}
+		return nodes
+	}
+
+	return &Diagram{Nodes: build(rootID)}, nil
+}
+
+func (pt xmlDgmPt) text() string {
+	if pt.T == nil {
+		return ""
+	}
+	var parts []string
+	for _, para := range pt.T.Paragraphs {
+		if text := paragraphText(para); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// --- XML structures ---
+
+type xmlDiagramRelIds struct {
+	DM string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships dm,attr"`
+}
+
+type xmlDataModel struct {
+	XMLName xml.Name    `xml:"dataModel"`
+	Points  []xmlDgmPt  `xml:"ptLst>pt"`
+	Cxns    []xmlDgmCxn `xml:"cxnLst>cxn"`
+}
+
+type xmlDgmPt struct {
+	ModelID string     `xml:"modelId,attr"`
+	Type    string     `xml:"type,attr"`
+	T       *xmlTxBody `xml:"t"`
+}
+
+type xmlDgmCxn struct {
+	Type   string `xml:"type,attr"`
+	SrcID  string `xml:"srcId,attr"`
+	DestID string `xml:"destId,attr"`
+	SrcOrd int    `xml:"srcOrd,attr"`
+}
@@ -0,0 +1,167 @@
+package pptx2md
+
+// Rect is a shape's bounding box on the slide, in EMU (914400 per inch).
+type Rect struct {
+	X, Y, W, H int64
+}
+
+// IsZero reports whether the rect carries no geometry.
+func (r Rect) IsZero() bool {
+	return r == Rect{}
+}
+
+// groupTransform maps coordinates inside nested group shapes onto the slide.
+// Each entry is a group's a:xfrm, outermost first; a child's offset is
+// relative to chOff/chExt of its group and scaled into the group's off/ext.
+type groupTransform []*xmlXfrm
+
+// nest returns the transform for children of a group with the given xfrm.
+func (g groupTransform) nest(xfrm *xmlXfrm) groupTransform {
+	if xfrm == nil {
+		return g
+	}
+	nested := make(groupTransform, len(g), len(g)+1)
+	copy(nested, g)
+	return append(nested, xfrm)
+}
+
+// bounds converts a shape xfrm into slide coordinates.
+func (g groupTransform) bounds(xfrm *xmlXfrm) Rect {
+	if xfrm == nil || xfrm.Off == nil {
+		return Rect{}
+	}
+	r := Rect{X: xfrm.Off.X, Y: xfrm.Off.Y}
+	if xfrm.Ext != nil {
+		r.W, r.H = xfrm.Ext.Cx, xfrm.Ext.Cy
+	}
+	for i := len(g) - 1; i >= 0; i-- {
+		r = g[i].apply(r)
+	}
+	return r
+}
+
+// apply maps a rect from a group's child space into the group's parent space.
+func (x *xmlXfrm) apply(r Rect) Rect {
+	if x.Off == nil {
+		return r
+	}
+	var chOff xmlPoint
+	if x.ChOff != nil {
+		chOff = *x.ChOff
+	}
+	sx, sy := 1.0, 1.0
+	if x.Ext != nil && x.ChExt != nil && x.ChExt.Cx > 0 && x.ChExt.Cy > 0 {
+		sx = float64(x.Ext.Cx) / float64(x.ChExt.Cx)
+		sy = float64(x.Ext.Cy) / float64(x.ChExt.Cy)
+	}
+	return Rect{
+		X: x.Off.X + int64(float64(r.X-chOff.X)*sx),
+		Y: x.Off.Y + int64(float64(r.Y-chOff.Y)*sy),
+		W: int64(float64(r.W) * sx),
+		H: int64(float64(r.H) * sy),
+	}
+}
+
+// captionGap is the largest vertical distance (in EMU) between an image and
+// a text box for the text box to count as the image's caption.
+const captionGap = 457200 // 0.5 inch
+
+// assignCaptions fills ImageRef.Caption for images without alt text, using the
+// nearest single-paragraph text box just below or above the image that
+// overlaps it horizontally. Text boxes used as captions are removed from the
+// slide's blocks, since they are written with their image.
+func assignCaptions(slide *Slide) {
+	captions := make(map[int]bool) // block index -> used as a caption
+	for i := range slide.Blocks {
+		block := &slide.Blocks[i]
+		if block.Kind != BlockImage || block.Image.Description != "" || block.Bounds.IsZero() {
+			continue
+		}
+		if j := findCaption(slide.Blocks, block.Bounds); j >= 0 {
+			block.Image.Caption = slide.Blocks[j].Paragraphs[0].Text
+			captions[j] = true
+		}
+	}
+	if len(captions) == 0 {
+		return
+	}
+	blocks := slide.Blocks[:0]
+	for i, block := range slide.Blocks {
+		if !captions[i] {
+			blocks = append(blocks, block)
+		}
+	}
+	slide.Blocks = blocks
+}
+
+// findCaption returns the index of the caption block for an image, or -1.
+func findCaption(blocks []Block, img Rect) int {
+	best, bestGap := -1, int64(captionGap)+1
+	for i, b := range blocks {
+		if b.Kind != BlockText || len(b.Paragraphs) != 1 || b.Bounds.IsZero() {
+			continue
+		}
+		if b.Bounds.X >= img.X+img.W || img.X >= b.Bounds.X+b.Bounds.W {
+			continue // no horizontal overlap
+		}
+		var gap int64
+		switch {
+		case b.Bounds.Y >= img.Y+img.H:
+			gap = b.Bounds.Y - (img.Y + img.H) // below
+		case b.Bounds.Y+b.Bounds.H <= img.Y:
+			gap = img.Y - (b.Bounds.Y + b.Bounds.H) + 1 // above, preferring below on ties
+		default:
+			continue
+		}
+		if gap < bestGap {
+			best, bestGap = i, gap
+		}
+	}
+	return best
+}
+
+// placeholderXfrm returns the geometry a placeholder inherits from its
+// layout or master placeholder when the slide does not override it.
+func (t *slideTemplate) placeholderXfrm(sp *xmlShape) *xmlXfrm {
+	ph := shapePlaceholder(sp)
+	if ph == nil {
+		return nil
+	}
+	if layoutSp := findPlaceholder(t.layout, ph); layoutSp != nil && layoutSp.xfrm() != nil {
+		return layoutSp.xfrm()
+	}
+	if masterSp := findMasterPlaceholder(t.master, ph); masterSp != nil {
+		return masterSp.xfrm()
+	}
+	return nil
+}
+
+// --- XML structures ---
+
+type xmlSpPr struct {
+	Xfrm *xmlXfrm `xml:"xfrm"`
+}
+
+func (p *xmlSpPr) xfrm() *xmlXfrm {
+	if p == nil {
+		return nil
+	}
+	return p.Xfrm
+}
+
+type xmlXfrm struct {
+	Off   *xmlPoint `xml:"off"`
+	Ext   *xmlSize  `xml:"ext"`
+	ChOff *xmlPoint `xml:"chOff"`
+	ChExt *xmlSize  `xml:"chExt"`
+}
+
+type xmlPoint struct {
+	X int64 `xml:"x,attr"`
+	Y int64 `xml:"y,attr"`
+}
+
+type xmlSize struct {
+	Cx int64 `xml:"cx,attr"`
+	Cy int64 `xml:"cy,attr"`
+}
@@ -0,0 +1,119 @@
+package pptx2md
+
+import "sort"
+
+// ReadingOrder selects how a slide's content blocks are ordered in the output.
+type ReadingOrder int
+
+const (
+	// ReadingOrderXML keeps the document order of the slide's shape tree.
+	ReadingOrderXML ReadingOrder = iota
+	// ReadingOrderTopToBottom reads blocks line by line: top to bottom,
+	// and left to right among blocks whose top edges line up.
+	ReadingOrderTopToBottom
+	// ReadingOrderColumns detects a two-column layout and reads the left
+	// column before the right one. Full-width blocks split the slide into
+	// bands that are read in turn. Falls back to top-to-bottom when no
+	// columns are found.
+	ReadingOrderColumns
+)
+
+// rowTolerance is how far apart (in EMU) two top edges may be and still
+// count as the same line, absorbing the slight misalignment of hand-placed boxes.
+const rowTolerance = 182880 // 0.2 inch
+
+// orderBlocks returns the blocks sorted for the given reading order.
+// slideWidth is used for column detection; 0 derives it from the blocks.
+func orderBlocks(blocks []Block, order ReadingOrder, slideWidth int64) []Block {
+	switch order {
+	case ReadingOrderTopToBottom:
+		return topToBottom(blocks)
+	case ReadingOrderColumns:
+		return columnsOrder(blocks, slideWidth)
+	default:
+		return blocks
+	}
+}
+
+func topToBottom(blocks []Block) []Block {
+	sorted := make([]Block, len(blocks))
+	copy(sorted, blocks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Bounds.Y < sorted[j].Bounds.Y
+	})
+
+	// Group blocks into rows by top edge, then read each row left to right
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && sorted[end].Bounds.Y-sorted[start].Bounds.Y <= rowTolerance {
+			end++
+		}
+		row := sorted[start:end]
+		sort.SliceStable(row, func(i, j int) bool {
+			return row[i].Bounds.X < row[j].Bounds.X
+		})
+		start = end
+	}
+	return sorted
+}
+
+func columnsOrder(blocks []Block, slideWidth int64) []Block {
+	if slideWidth <= 0 {
+		for _, b := range blocks {
+			if right := b.Bounds.X + b.Bounds.W; right > slideWidth {
+				slideWidth = right
+			}
+		}
+	}
+	mid := slideWidth / 2
+	slack := slideWidth / 20 // columns may overlap the center line slightly
+
+	const (
+		spanning = iota
+		leftCol
+		rightCol
+	)
+	column := func(b Block) int {
+		switch {
+		case b.Bounds.X+b.Bounds.W <= mid+slack:
+			return leftCol
+		case b.Bounds.X >= mid-slack:
+			return rightCol
+		default:
+			return spanning
+		}
+	}
+
+	var hasLeft, hasRight bool
+	for _, b := range blocks {
+		switch column(b) {
+		case leftCol:
+			hasLeft = true
+		case rightCol:
+			hasRight = true
+		}
+	}
+	if !hasLeft || !hasRight {
+		return topToBottom(blocks)
+	}
+
+	var out, left, right []Block
+	flush := func() {
+		out = append(out, left...)
+		out = append(out, right...)
+		left, right = nil, nil
+	}
+	for _, b := range topToBottom(blocks) {
+		switch column(b) {
+		case leftCol:
+			left = append(left, b)
+		case rightCol:
+			right = append(right, b)
+		default:
+			flush()
+			out = append(out, b)
+		}
+	}
+	flush()
+	return out
+}
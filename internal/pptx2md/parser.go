@@ -7,33 +7,202 @@ import (
 	"io"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
+
+	"ar-tools/internal/mdtable"
 )
 
 // Slide represents a single parsed slide.
 type Slide struct {
 	Index  int
+	ID     int // p:sldId id from presentation.xml, 0 if unknown
 	Title  string
-	Bodies []string   // text paragraphs (non-title)
-	Images []ImageRef // image references
-	Tables []Table    // tables from graphicFrame elements
+	Hidden bool     // hidden in the slide show (p:sld show="0")
+	Blocks []Block  // shape contents in document order
+	Notes  []string // speaker notes paragraphs
+}
+
+// Bodies returns the text paragraphs (non-title) of the slide.
+func (s *Slide) Bodies() []string {
+	var bodies []string
+	for _, para := range s.Paragraphs() {
+		bodies = append(bodies, para.Text)
+	}
+	return bodies
+}
+
+// Paragraphs returns the same paragraphs as Bodies, with list formatting.
+func (s *Slide) Paragraphs() []Paragraph {
+	var paras []Paragraph
+	for _, b := range s.Blocks {
+		if b.Kind == BlockText {
+			paras = append(paras, b.Paragraphs...)
+		}
+	}
+	return paras
+}
+
+// Images returns the slide's image references.
+func (s *Slide) Images() []ImageRef {
+	var images []ImageRef
+	for _, b := range s.Blocks {
+		if b.Kind == BlockImage {
+			images = append(images, *b.Image)
+		}
+	}
+	return images
+}
+
+// Tables returns the tables from graphicFrame elements.
+func (s *Slide) Tables() []Table {
+	var tables []Table
+	for _, b := range s.Blocks {
+		if b.Kind == BlockTable {
+			tables = append(tables, *b.Table)
+		}
+	}
+	return tables
+}
+
+// Charts returns the charts from graphicFrame elements.
+func (s *Slide) Charts() []Chart {
+	var charts []Chart
+	for _, b := range s.Blocks {
+		if b.Kind == BlockChart {
+			charts = append(charts, *b.Chart)
+		}
+	}
+	return charts
+}
+
+// Diagrams returns the SmartArt graphics from graphicFrame elements.
+func (s *Slide) Diagrams() []Diagram {
+	var diagrams []Diagram
+	for _, b := range s.Blocks {
+		if b.Kind == BlockDiagram {
+			diagrams = append(diagrams, *b.Diagram)
+		}
+	}
+	return diagrams
+}
+
+// BlockKind identifies the content held by a Block.
+type BlockKind int
+
+const (
+	BlockText BlockKind = iota
+	BlockImage
+	BlockTable
+	BlockChart
+	BlockDiagram
+)
+
+// Block is the content of one shape. Slide.Blocks interleaves text, images
+// and tables the way they appear on the slide; Bodies, Paragraphs, Images and
+// Tables return the same content flattened per kind.
+type Block struct {
+	Kind       BlockKind
+	Bounds     Rect        // position on the slide; zero if the shape has no geometry
+	Paragraphs []Paragraph // BlockText
+	Image      *ImageRef   // BlockImage
+	Table      *Table      // BlockTable
+	Chart      *Chart      // BlockChart
+	Diagram    *Diagram    // BlockDiagram
+}
+
+// BulletKind describes how a paragraph is marked as a list item.
+type BulletKind int
+
+const (
+	BulletNone   BulletKind = iota // plain paragraph (a:buNone or no bullet inherited)
+	BulletChar                     // symbol or picture bullet (a:buChar, a:buBlip)
+	BulletNumber                   // auto-numbered item (a:buAutoNum)
+)
+
+// Paragraph is a body text paragraph with its outline level and bullet style.
+type Paragraph struct {
+	Text   string
+	Level  int // outline level from a:pPr lvl, 0-8
+	Bullet BulletKind
+	Runs   []Run // formatted spans; their texts concatenate to Text
+}
+
+// Run is a span of paragraph text sharing the same character formatting.
+type Run struct {
+	Text      string
+	Bold      bool
+	Italic    bool
+	Strike    bool
+	Underline bool
+	Code      bool   // set in a monospace latin font such as Consolas or Courier
+	Link      string // external hyperlink target, empty if none
 }
 
 // Table represents a table extracted from a slide.
 type Table struct {
-	Rows [][]string // rows of cells, each cell is its text content
+	Rows      [][]string   // rows of cells, each cell is its text content
+	Spans     [][]CellSpan // merge info parallel to Rows; nil when no cells are merged
+	ColWidths []int64      // grid column widths in EMU from a:tblGrid, empty if not declared
+}
+
+// CellSpan describes how a table cell takes part in a merge. An anchor cell
+// spans ColSpan columns and RowSpan rows; the cells under it are Covered.
+type CellSpan struct {
+	ColSpan int // 1 for an unmerged cell
+	RowSpan int // 1 for an unmerged cell
+	Covered bool
+}
+
+// mergedRanges lists the table's merged ranges for the mdtable writer.
+func (t Table) mergedRanges() []mdtable.MergedRange {
+	var merges []mdtable.MergedRange
+	for i, row := range t.Spans {
+		for j, span := range row {
+			if !span.Covered && (span.ColSpan > 1 || span.RowSpan > 1) {
+				merges = append(merges, mdtable.MergedRange{Row: i, Col: j, Rows: span.RowSpan, Cols: span.ColSpan})
+			}
+		}
+	}
+	return merges
 }
 
 // ImageRef links an image to its media path inside the ZIP.
 type ImageRef struct {
-	RelID     string
-	MediaPath string // e.g. "ppt/media/image1.png"
+	RelID       string
+	MediaPath   string // e.g. "ppt/media/image1.png"
+	Name        string // shape name from p:cNvPr, e.g. "Picture 3"
+	Description string // alt text from p:cNvPr descr
+	Title       string // p:cNvPr title
+	Caption     string // text of the caption box next to the image (dropped from Blocks), used when Description is empty
 }
 
 // Presentation holds all parsed slides and a handle to the ZIP for media extraction.
 type Presentation struct {
-	Slides []*Slide
-	zip    *zip.ReadCloser
+	Slides      []*Slide
+	SlideWidth  int64     // EMU, from p:sldSz; 0 if not declared
+	SlideHeight int64     // EMU
+	Sections    []Section // PowerPoint sections, empty if the deck has none
+	zip         *zip.ReadCloser
+}
+
+// Section is a named group of slides from p14:sectionLst.
+type Section struct {
+	Name     string
+	SlideIDs []int // Slide.ID of each slide in the section, in order
+}
+
+// SectionOf returns the index in Sections of the section containing the
+// slide, or -1 if it belongs to none.
+func (p *Presentation) SectionOf(slide *Slide) int {
+	for i, sec := range p.Sections {
+		for _, id := range sec.SlideIDs {
+			if id == slide.ID && id != 0 {
+				return i
+			}
+		}
+	}
+	return -1
 }
 
 // Close releases the underlying ZIP reader.
@@ -44,6 +213,29 @@ func (p *Presentation) Close() error {
 	return nil
 }
 
+// HiddenSlideMode selects how slides hidden in the slide show are converted.
+type HiddenSlideMode int
+
+const (
+	HiddenSlidesInclude HiddenSlideMode = iota // convert like any other slide
+	HiddenSlidesExclude                        // leave hidden slides out
+	HiddenSlidesMark                           // convert them with a "hidden" marker
+)
+
+// FilterSlides returns the slides to convert under the given hidden-slide mode.
+func (p *Presentation) FilterSlides(mode HiddenSlideMode) []*Slide {
+	if mode != HiddenSlidesExclude {
+		return p.Slides
+	}
+	var slides []*Slide
+	for _, slide := range p.Slides {
+		if !slide.Hidden {
+			slides = append(slides, slide)
+		}
+	}
+	return slides
+}
+
 // ReadMedia reads a media file from the PPTX archive.
 func (p *Presentation) ReadMedia(mediaPath string) ([]byte, error) {
 	for _, f := range p.zip.File {
@@ -67,43 +259,83 @@ func Parse(filePath string) (*Presentation, error) {
 	}
 
 	pres := &Presentation{zip: zr}
+	templates := make(map[string]*slideTemplate)
+
+	presXML, err := readPresentationXML(zr)
+	if err != nil {
+		zr.Close()
+		return nil, err
+	}
+	if presXML.SlideSize != nil {
+		pres.SlideWidth = presXML.SlideSize.Cx
+		pres.SlideHeight = presXML.SlideSize.Cy
+	}
+	pres.Sections = presXML.sections()
 
-	slideOrder, err := getSlideOrder(zr)
+	slideOrder, err := getSlideOrder(zr, presXML)
 	if err != nil {
 		zr.Close()
 		return nil, err
 	}
 
-	for i, slidePath := range slideOrder {
-		slide, err := parseSlide(zr, slidePath, i+1)
+	for i, ref := range slideOrder {
+		slide, err := parseSlide(zr, ref.path, i+1, templates)
 		if err != nil {
 			zr.Close()
-			return nil, fmt.Errorf("failed to parse %s: %w", slidePath, err)
+			return nil, fmt.Errorf("failed to parse %s: %w", ref.path, err)
 		}
+		slide.ID = ref.id
 		pres.Slides = append(pres.Slides, slide)
 	}
 
 	return pres, nil
 }
 
-// getSlideOrder determines slide ordering from presentation.xml and its rels.
-func getSlideOrder(zr *zip.ReadCloser) ([]string, error) {
-	presRels, err := parseRels(zr, "ppt/_rels/presentation.xml.rels")
-	if err != nil {
-		return nil, fmt.Errorf("failed to read presentation rels: %w", err)
-	}
-
-	presXML, err := readZipFile(zr, "ppt/presentation.xml")
+func readPresentationXML(zr *zip.ReadCloser) (*xmlPresentation, error) {
+	data, err := readZipFile(zr, "ppt/presentation.xml")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read presentation.xml: %w", err)
 	}
 
 	var pres xmlPresentation
-	if err := xml.Unmarshal(presXML, &pres); err != nil {
+	if err := xml.Unmarshal(data, &pres); err != nil {
 		return nil, fmt.Errorf("failed to parse presentation.xml: %w", err)
 	}
+	return &pres, nil
+}
 
-	var slides []string
+// sections lists the deck's sections; slides are referenced by p:sldId id.
+func (p *xmlPresentation) sections() []Section {
+	var sections []Section
+	for _, ext := range p.ExtLst {
+		if ext.SectionList == nil {
+			continue
+		}
+		for _, sec := range ext.SectionList.Sections {
+			section := Section{Name: sec.Name}
+			for _, sid := range sec.SlideIds {
+				section.SlideIDs = append(section.SlideIDs, sid.ID)
+			}
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// slideRef locates a slide part and its p:sldId id.
+type slideRef struct {
+	path string
+	id   int
+}
+
+// getSlideOrder determines slide ordering from presentation.xml and its rels.
+func getSlideOrder(zr *zip.ReadCloser, pres *xmlPresentation) ([]slideRef, error) {
+	presRels, err := parseRels(zr, "ppt/_rels/presentation.xml.rels")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read presentation rels: %w", err)
+	}
+
+	var slides []slideRef
 	for _, sid := range pres.SlideIdList.SlideIds {
 		relTarget, ok := presRels[sid.RID]
 		if !ok {
@@ -111,12 +343,14 @@ func getSlideOrder(zr *zip.ReadCloser) ([]string, error) {
 		}
 		// Resolve relative path: targets are relative to ppt/
 		slidePath := resolveRelPath("ppt", relTarget)
-		slides = append(slides, slidePath)
+		slides = append(slides, slideRef{path: slidePath, id: sid.ID})
 	}
 
 	if len(slides) == 0 {
 		// Fallback: scan for slide files directly
-		slides = scanSlideFiles(zr)
+		for _, slidePath := range scanSlideFiles(zr) {
+			slides = append(slides, slideRef{path: slidePath})
+		}
 	}
 
 	return slides, nil
@@ -136,7 +370,7 @@ func scanSlideFiles(zr *zip.ReadCloser) []string {
 	return slides
 }
 
-func parseSlide(zr *zip.ReadCloser, slidePath string, index int) (*Slide, error) {
+func parseSlide(zr *zip.ReadCloser, slidePath string, index int, templates map[string]*slideTemplate) (*Slide, error) {
 	data, err := readZipFile(zr, slidePath)
 	if err != nil {
 		return nil, err
@@ -149,52 +383,113 @@ func parseSlide(zr *zip.ReadCloser, slidePath string, index int) (*Slide, error)
 
 	// Parse rels for this slide
 	relsPath := slideRelsPath(slidePath)
-	slideRels, _ := parseRels(zr, relsPath)
-
-	slide := &Slide{Index: index}
-
-	// Extract shapes (text + images)
-	for _, sp := range sld.CSld.SpTree.Shapes {
-		extractShapeText(sp, slide)
+	relList, _ := parseRelList(zr, relsPath)
+
+	slide := &Slide{Index: index, Hidden: sld.Show == "0" || sld.Show == "false"}
+	ctx := &slideContext{
+		zr:    zr,
+		dir:   path.Dir(slidePath),
+		rels:  relsToMap(relList),
+		links: make(map[string]string),
+		tmpl:  &slideTemplate{},
 	}
-
-	// Extract grouped shapes
-	for _, grp := range sld.CSld.SpTree.GroupShapes {
-		for _, sp := range grp.Shapes {
-			extractShapeText(sp, slide)
-		}
-		for _, pic := range grp.Pictures {
-			extractPicture(pic, slide, slideRels)
+	for _, rel := range relList {
+		switch {
+		case strings.HasSuffix(rel.Type, "/slideLayout"):
+			// The layout/master the slide inherits list styles from
+			ctx.tmpl = loadTemplate(zr, resolveRelPath(path.Dir(slidePath), rel.Target), templates)
+		case strings.HasSuffix(rel.Type, "/hyperlink") && rel.TargetMode == "External":
+			ctx.links[rel.ID] = rel.Target
 		}
-		for _, gf := range grp.GraphicFrames {
-			extractTable(gf, slide)
+	}
+
+	// Extract shape contents in document order, descending into groups
+	extractTree(&sld.CSld.SpTree, slide, ctx, nil)
+	assignCaptions(slide)
+
+	// Extract speaker notes from the linked notes slide
+	for _, rel := range relList {
+		if strings.HasSuffix(rel.Type, "/notesSlide") {
+			// Notes are auxiliary; an unreadable notes part should not fail the slide
+			slide.Notes, _ = parseNotes(zr, resolveRelPath(path.Dir(slidePath), rel.Target))
+			break
 		}
 	}
 
-	// Extract pictures
-	for _, pic := range sld.CSld.SpTree.Pictures {
-		extractPicture(pic, slide, slideRels)
+	return slide, nil
+}
+
+// parseNotes extracts the paragraphs of the body placeholder of a notes slide.
+func parseNotes(zr *zip.ReadCloser, notesPath string) ([]string, error) {
+	data, err := readZipFile(zr, notesPath)
+	if err != nil {
+		return nil, err
 	}
 
-	// Extract tables from graphicFrame elements
-	for _, gf := range sld.CSld.SpTree.GraphicFrames {
-		extractTable(gf, slide)
+	var notes xmlNotes
+	if err := xml.Unmarshal(data, &notes); err != nil {
+		return nil, fmt.Errorf("failed to parse notes XML: %w", err)
 	}
 
-	// Extract text from connector shapes
-	for _, cxn := range sld.CSld.SpTree.ConnShapes {
-		extractConnShapeText(cxn, slide)
+	var paras []string
+	for _, sp := range notes.CSld.SpTree.shapes() {
+		if sp.TxBody == nil || placeholderType(sp) != "body" {
+			continue
+		}
+		for _, para := range sp.TxBody.Paragraphs {
+			if text := paragraphText(para); text != "" {
+				paras = append(paras, text)
+			}
+		}
 	}
+	return paras, nil
+}
 
-	return slide, nil
+// slideContext carries the slide-level lookups shape extraction resolves against.
+type slideContext struct {
+	zr    *zip.ReadCloser
+	dir   string            // directory of the slide part, for resolving rel targets
+	rels  map[string]string // rel id -> target
+	links map[string]string // rel id -> external hyperlink URL
+	tmpl  *slideTemplate
 }
 
-func extractShapeText(sp xmlShape, slide *Slide) {
+// extractTree walks a shape tree in document order, recursing into group
+// shapes. xf maps the tree's coordinates onto the slide.
+func extractTree(tree *xmlSpTree, slide *Slide, ctx *slideContext, xf groupTransform) {
+	for _, child := range tree.Children {
+		switch {
+		case child.Shape != nil:
+			xfrm := child.Shape.xfrm()
+			if xfrm == nil {
+				xfrm = ctx.tmpl.placeholderXfrm(child.Shape)
+			}
+			extractShapeText(*child.Shape, slide, ctx, xf.bounds(xfrm))
+		case child.Picture != nil:
+			extractPicture(*child.Picture, slide, ctx.rels, xf.bounds(child.Picture.SpPr.xfrm()))
+		case child.Frame != nil:
+			extractGraphicFrame(*child.Frame, slide, ctx, xf.bounds(child.Frame.Xfrm))
+		case child.Conn != nil:
+			extractConnShapeText(*child.Conn, slide, ctx, xf.bounds(child.Conn.SpPr.xfrm()))
+		case child.Group != nil:
+			extractTree(child.Group, slide, ctx, xf.nest(child.Group.GrpSpPr.xfrm()))
+		}
+	}
+}
+
+func extractShapeText(sp xmlShape, slide *Slide, ctx *slideContext, bounds Rect) {
+	links := ctx.links
+	if !hasText(sp.TxBody) {
+		// Empty placeholders show the text of their layout placeholder
+		sp.TxBody = ctx.tmpl.placeholderText(&sp)
+		links = nil // layout hyperlinks resolve against the layout's rels
+	}
 	if sp.TxBody == nil {
 		return
 	}
 
-	isTitle := isPlaceholderTitle(sp)
+	isTitle := isTitleType(ctx.tmpl.placeholderType(&sp))
+	var paras []Paragraph
 	for _, para := range sp.TxBody.Paragraphs {
 		text := paragraphText(para)
 		if text == "" {
@@ -203,12 +498,25 @@ func extractShapeText(sp xmlShape, slide *Slide) {
 		if isTitle && slide.Title == "" {
 			slide.Title = text
 		} else {
-			slide.Bodies = append(slide.Bodies, text)
+			paras = append(paras, Paragraph{
+				Text:   text,
+				Level:  para.level(),
+				Bullet: ctx.tmpl.bullet(&sp, para),
+				Runs:   paragraphRuns(para, links),
+			})
 		}
 	}
+	addTextBlock(slide, paras, bounds)
+}
+
+func addTextBlock(slide *Slide, paras []Paragraph, bounds Rect) {
+	if len(paras) == 0 {
+		return
+	}
+	slide.Blocks = append(slide.Blocks, Block{Kind: BlockText, Bounds: bounds, Paragraphs: paras})
 }
 
-func extractPicture(pic xmlPicture, slide *Slide, rels map[string]string) {
+func extractPicture(pic xmlPicture, slide *Slide, rels map[string]string, bounds Rect) {
 	if pic.BlipFill == nil || pic.BlipFill.Blip == nil {
 		return
 	}
@@ -217,27 +525,65 @@ func extractPicture(pic xmlPicture, slide *Slide, rels map[string]string) {
 		return
 	}
 	ref := ImageRef{RelID: rID}
+	if pic.NvPicPr != nil && pic.NvPicPr.CNvPr != nil {
+		ref.Name = pic.NvPicPr.CNvPr.Name
+		ref.Description = strings.TrimSpace(pic.NvPicPr.CNvPr.Descr)
+		ref.Title = strings.TrimSpace(pic.NvPicPr.CNvPr.Title)
+	}
 	if target, ok := rels[rID]; ok {
 		ref.MediaPath = resolveRelPath("ppt/slides", target)
 	}
-	slide.Images = append(slide.Images, ref)
+	slide.Blocks = append(slide.Blocks, Block{Kind: BlockImage, Bounds: bounds, Image: &ref})
 }
 
-func extractTable(gf xmlGraphicFrame, slide *Slide) {
-	if gf.Graphic == nil || gf.Graphic.GraphicData == nil || gf.Graphic.GraphicData.Table == nil {
+// extractGraphicFrame dispatches a graphicFrame on the kind of graphic it holds.
+func extractGraphicFrame(gf xmlGraphicFrame, slide *Slide, ctx *slideContext, bounds Rect) {
+	if gf.Graphic == nil || gf.Graphic.GraphicData == nil {
 		return
 	}
-	tbl := gf.Graphic.GraphicData.Table
+	data := gf.Graphic.GraphicData
+	switch {
+	case data.Table != nil:
+		extractTable(data.Table, slide, bounds)
+	case data.Chart != nil:
+		extractChart(data.Chart.RID, slide, ctx, bounds)
+	case data.Diagram != nil:
+		extractDiagram(data.Diagram.DM, slide, ctx, bounds)
+	}
+}
+
+func extractTable(tbl *xmlTable, slide *Slide, bounds Rect) {
 	var rows [][]string
+	var spans [][]CellSpan
+	merged := false
 	for _, tr := range tbl.Rows {
 		var cells []string
+		var rowSpans []CellSpan
 		for _, tc := range tr.Cells {
 			cells = append(cells, cellText(tc))
+			// Covered cells stay in the grid (hMerge/vMerge), so columns never shift
+			span := CellSpan{
+				ColSpan: max(tc.GridSpan, 1),
+				RowSpan: max(tc.RowSpan, 1),
+				Covered: isTrue(tc.HMerge) || isTrue(tc.VMerge),
+			}
+			if span != (CellSpan{ColSpan: 1, RowSpan: 1}) {
+				merged = true
+			}
+			rowSpans = append(rowSpans, span)
 		}
 		rows = append(rows, cells)
+		spans = append(spans, rowSpans)
 	}
 	if len(rows) > 0 {
-		slide.Tables = append(slide.Tables, Table{Rows: rows})
+		table := Table{Rows: rows}
+		if merged {
+			table.Spans = spans
+		}
+		for _, col := range tbl.Grid {
+			table.ColWidths = append(table.ColWidths, col.W)
+		}
+		slide.Blocks = append(slide.Blocks, Block{Kind: BlockTable, Bounds: bounds, Table: &table})
 	}
 }
 
@@ -255,24 +601,44 @@ func cellText(tc xmlTableCell) string {
 	return strings.Join(parts, "\n")
 }
 
-func extractConnShapeText(cxn xmlConnShape, slide *Slide) {
+func extractConnShapeText(cxn xmlConnShape, slide *Slide, ctx *slideContext, bounds Rect) {
 	if cxn.TxBody == nil {
 		return
 	}
+	var paras []Paragraph
 	for _, para := range cxn.TxBody.Paragraphs {
 		text := paragraphText(para)
 		if text != "" {
-			slide.Bodies = append(slide.Bodies, text)
+			paras = append(paras, Paragraph{
+				Text:   text,
+				Level:  para.level(),
+				Bullet: ctx.tmpl.bullet(&xmlShape{TxBody: cxn.TxBody}, para),
+				Runs:   paragraphRuns(para, ctx.links),
+			})
 		}
 	}
+	addTextBlock(slide, paras, bounds)
+}
+
+// placeholderType returns the ph type of a shape, or "" if it is not a placeholder.
+func placeholderType(sp xmlShape) string {
+	if ph := shapePlaceholder(&sp); ph != nil {
+		return ph.Type
+	}
+	return ""
 }
 
-func isPlaceholderTitle(sp xmlShape) bool {
-	if sp.NvSpPr == nil || sp.NvSpPr.NvPr == nil || sp.NvSpPr.NvPr.Ph == nil {
+// hasText reports whether a text body holds any non-empty paragraph.
+func hasText(body *xmlTxBody) bool {
+	if body == nil {
 		return false
 	}
-	phType := sp.NvSpPr.NvPr.Ph.Type
-	return phType == "title" || phType == "ctrTitle"
+	for _, para := range body.Paragraphs {
+		if paragraphText(para) != "" {
+			return true
+		}
+	}
+	return false
 }
 
 func paragraphText(para xmlParagraph) string {
@@ -282,17 +648,72 @@ func paragraphText(para xmlParagraph) string {
 			parts = append(parts, run.Text)
 		}
 	}
-	for _, fld := range para.Fields {
-		if fld.Text != "" {
-			parts = append(parts, fld.Text)
+	return strings.Join(parts, "")
+}
+
+// paragraphRuns converts text runs and fields into formatted Runs, resolving
+// hyperlink rel ids through links.
+func paragraphRuns(para xmlParagraph, links map[string]string) []Run {
+	var runs []Run
+	for _, r := range para.Runs {
+		if r.Text == "" {
+			continue
 		}
+		run := Run{Text: r.Text}
+		if rPr := r.RPr; rPr != nil {
+			run.Bold = isTrue(rPr.B)
+			run.Italic = isTrue(rPr.I)
+			run.Strike = rPr.Strike != "" && rPr.Strike != "noStrike"
+			run.Underline = rPr.U != "" && rPr.U != "none"
+			run.Code = rPr.Latin != nil && isMonospaceFont(rPr.Latin.Typeface)
+			if rPr.HlinkClick != nil {
+				run.Link = links[rPr.HlinkClick.RID]
+			}
+		}
+		runs = append(runs, run)
 	}
-	return strings.Join(parts, "")
+	return runs
+}
+
+func isTrue(v string) bool {
+	return v == "1" || v == "true"
+}
+
+// monospaceFonts lists lower-cased typeface families rendered as inline code.
+var monospaceFonts = []string{
+	"consolas", "courier", "lucida console", "lucida sans typewriter", "menlo",
+	"monaco", "cascadia", "source code", "fira code", "inconsolata",
+}
+
+// isMonospaceFont reports whether a typeface is one of monospaceFonts or
+// names itself monospaced with a "Mono" word, as in "JetBrains Mono".
+func isMonospaceFont(typeface string) bool {
+	name := strings.ToLower(typeface)
+	for _, f := range monospaceFonts {
+		if name == f || strings.HasPrefix(name, f+" ") {
+			return true
+		}
+	}
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == ' ' || r == '-' }) {
+		if word == "mono" || word == "monospace" || word == "monospaced" {
+			return true
+		}
+	}
+	return false
 }
 
 // --- Rels parsing ---
 
 func parseRels(zr *zip.ReadCloser, relsPath string) (map[string]string, error) {
+	list, err := parseRelList(zr, relsPath)
+	if err != nil {
+		return nil, err
+	}
+	return relsToMap(list), nil
+}
+
+// parseRelList returns all relationships of a rels part, keeping their types.
+func parseRelList(zr *zip.ReadCloser, relsPath string) ([]xmlRelationship, error) {
 	data, err := readZipFile(zr, relsPath)
 	if err != nil {
 		return nil, err
@@ -302,12 +723,15 @@ func parseRels(zr *zip.ReadCloser, relsPath string) (map[string]string, error) {
 	if err := xml.Unmarshal(data, &rels); err != nil {
 		return nil, err
 	}
+	return rels.Relationships, nil
+}
 
-	m := make(map[string]string, len(rels.Relationships))
-	for _, r := range rels.Relationships {
+func relsToMap(list []xmlRelationship) map[string]string {
+	m := make(map[string]string, len(list))
+	for _, r := range list {
 		m[r.ID] = r.Target
 	}
-	return m, nil
+	return m
 }
 
 func slideRelsPath(slidePath string) string {
@@ -346,6 +770,21 @@ func readZipFile(zr *zip.ReadCloser, name string) ([]byte, error) {
 type xmlPresentation struct {
 	XMLName     xml.Name       `xml:"presentation"`
 	SlideIdList xmlSlideIdList `xml:"sldIdLst"`
+	SlideSize   *xmlSize       `xml:"sldSz"`
+	ExtLst      []xmlPresExt   `xml:"extLst>ext"`
+}
+
+type xmlPresExt struct {
+	SectionList *xmlSectionList `xml:"sectionLst"`
+}
+
+type xmlSectionList struct {
+	Sections []xmlSection `xml:"section"`
+}
+
+type xmlSection struct {
+	Name     string       `xml:"name,attr"`
+	SlideIds []xmlSlideId `xml:"sldIdLst>sldId"`
 }
 
 type xmlSlideIdList struct {
@@ -353,11 +792,34 @@ type xmlSlideIdList struct {
 }
 
 type xmlSlideId struct {
-	RID string `xml:"id,attr"`
+	ID  int    // id
+	RID string // r:id
+}
+
+// UnmarshalXML tells id from r:id, which encoding/xml struct tags cannot
+// do since an un-namespaced attr tag matches both.
+func (s *xmlSlideId) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local != "id" {
+			continue
+		}
+		if attr.Name.Space == "" {
+			s.ID, _ = strconv.Atoi(attr.Value)
+		} else {
+			s.RID = attr.Value
+		}
+	}
+	return d.Skip()
 }
 
 type xmlSlide struct {
 	XMLName xml.Name `xml:"sld"`
+	Show    string   `xml:"show,attr"`
+	CSld    xmlCSld  `xml:"cSld"`
+}
+
+type xmlNotes struct {
+	XMLName xml.Name `xml:"notes"`
 	CSld    xmlCSld  `xml:"cSld"`
 }
 
@@ -365,25 +827,94 @@ type xmlCSld struct {
 	SpTree xmlSpTree `xml:"spTree"`
 }
 
+// xmlSpTree holds the children of p:spTree or p:grpSp in document order.
+// Group shapes nest another xmlSpTree, so groups of any depth are kept.
 type xmlSpTree struct {
-	Shapes         []xmlShape         `xml:"sp"`
-	Pictures       []xmlPicture       `xml:"pic"`
-	GroupShapes    []xmlGroupShape    `xml:"grpSp"`
-	GraphicFrames  []xmlGraphicFrame  `xml:"graphicFrame"`
-	ConnShapes     []xmlConnShape     `xml:"cxnSp"`
+	GrpSpPr  *xmlSpPr // group geometry; nil for the slide's root tree
+	Children []xmlSpTreeChild
 }
 
-type xmlGroupShape struct {
-	Shapes        []xmlShape         `xml:"sp"`
-	Pictures      []xmlPicture       `xml:"pic"`
-	GraphicFrames []xmlGraphicFrame  `xml:"graphicFrame"`
+// xmlSpTreeChild holds exactly one shape tree element.
+type xmlSpTreeChild struct {
+	Shape   *xmlShape
+	Picture *xmlPicture
+	Frame   *xmlGraphicFrame
+	Conn    *xmlConnShape
+	Group   *xmlSpTree
+}
+
+func (t *xmlSpTree) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			var child xmlSpTreeChild
+			var target any
+			switch el.Name.Local {
+			case "sp":
+				child.Shape = &xmlShape{}
+				target = child.Shape
+			case "pic":
+				child.Picture = &xmlPicture{}
+				target = child.Picture
+			case "graphicFrame":
+				child.Frame = &xmlGraphicFrame{}
+				target = child.Frame
+			case "cxnSp":
+				child.Conn = &xmlConnShape{}
+				target = child.Conn
+			case "grpSp":
+				child.Group = &xmlSpTree{}
+				target = child.Group
+			case "grpSpPr":
+				t.GrpSpPr = &xmlSpPr{}
+				if err := d.DecodeElement(t.GrpSpPr, &el); err != nil {
+					return err
+				}
+				continue
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.DecodeElement(target, &el); err != nil {
+				return err
+			}
+			t.Children = append(t.Children, child)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// shapes returns every p:sp in the tree, including those nested in groups.
+func (t *xmlSpTree) shapes() []xmlShape {
+	var out []xmlShape
+	for _, child := range t.Children {
+		switch {
+		case child.Shape != nil:
+			out = append(out, *child.Shape)
+		case child.Group != nil:
+			out = append(out, child.Group.shapes()...)
+		}
+	}
+	return out
 }
 
 type xmlShape struct {
 	NvSpPr *xmlNvSpPr `xml:"nvSpPr"`
+	SpPr   *xmlSpPr   `xml:"spPr"`
 	TxBody *xmlTxBody `xml:"txBody"`
 }
 
+func (sp *xmlShape) xfrm() *xmlXfrm {
+	return sp.SpPr.xfrm()
+}
+
 type xmlNvSpPr struct {
 	NvPr *xmlNvPr `xml:"nvPr"`
 }
@@ -393,32 +924,88 @@ type xmlNvPr struct {
 }
 
 type xmlPh struct {
-	Type string `xml:"type,attr"`
+	Type            string `xml:"type,attr"`
+	Idx             string `xml:"idx,attr"`
+	HasCustomPrompt string `xml:"hasCustomPrompt,attr"`
 }
 
 type xmlTxBody struct {
+	LstStyle   *xmlListStyle  `xml:"lstStyle"`
 	Paragraphs []xmlParagraph `xml:"p"`
 }
 
 type xmlParagraph struct {
-	Runs   []xmlRun   `xml:"r"`
-	Fields []xmlField `xml:"fld"`
+	PPr  *xmlPPr  `xml:"pPr"`
+	Runs []xmlRun `xml:",any"` // a:r and a:fld in document order
+}
+
+// level returns the paragraph outline level (a:pPr lvl), defaulting to 0.
+func (p xmlParagraph) level() int {
+	if p.PPr == nil {
+		return 0
+	}
+	return p.PPr.Lvl
 }
 
+// xmlPPr is used both for a:pPr and for the a:lvlNpPr entries of a list style.
+type xmlPPr struct {
+	XMLName   xml.Name
+	Lvl       int       `xml:"lvl,attr"`
+	BuNone    *xmlEmpty `xml:"buNone"`
+	BuChar    *xmlEmpty `xml:"buChar"`
+	BuAutoNum *xmlEmpty `xml:"buAutoNum"`
+	BuBlip    *xmlEmpty `xml:"buBlip"`
+}
+
+type xmlEmpty struct{}
+
+type xmlListStyle struct {
+	Levels []xmlPPr `xml:",any"`
+}
+
+// xmlRun covers a:r and a:fld; other paragraph children (a:br, a:endParaRPr) carry no text.
 type xmlRun struct {
-	Text string `xml:"t"`
+	XMLName xml.Name
+	RPr     *xmlRPr `xml:"rPr"`
+	Text    string  `xml:"t"`
 }
 
-type xmlField struct {
-	Text string `xml:"t"`
+type xmlRPr struct {
+	B          string        `xml:"b,attr"`
+	I          string        `xml:"i,attr"`
+	U          string        `xml:"u,attr"`
+	Strike     string        `xml:"strike,attr"`
+	Latin      *xmlFont      `xml:"latin"`
+	HlinkClick *xmlHyperlink `xml:"hlinkClick"`
+}
+
+type xmlFont struct {
+	Typeface string `xml:"typeface,attr"`
+}
+
+type xmlHyperlink struct {
+	RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
 }
 
 type xmlConnShape struct {
+	SpPr   *xmlSpPr   `xml:"spPr"`
 	TxBody *xmlTxBody `xml:"txBody"`
 }
 
 type xmlPicture struct {
+	NvPicPr  *xmlNvPicPr  `xml:"nvPicPr"`
 	BlipFill *xmlBlipFill `xml:"blipFill"`
+	SpPr     *xmlSpPr     `xml:"spPr"`
+}
+
+type xmlNvPicPr struct {
+	CNvPr *xmlCNvPr `xml:"cNvPr"`
+}
+
+type xmlCNvPr struct {
+	Name  string `xml:"name,attr"`
+	Descr string `xml:"descr,attr"`
+	Title string `xml:"title,attr"`
 }
 
 type xmlBlipFill struct {
@@ -430,6 +1017,7 @@ type xmlBlip struct {
 }
 
 type xmlGraphicFrame struct {
+	Xfrm    *xmlXfrm    `xml:"xfrm"`
 	Graphic *xmlGraphic `xml:"graphic"`
 }
 
@@ -438,20 +1026,35 @@ type xmlGraphic struct {
 }
 
 type xmlGraphicData struct {
-	URI   string    `xml:"uri,attr"`
-	Table *xmlTable `xml:"tbl"`
+	URI     string            `xml:"uri,attr"`
+	Table   *xmlTable         `xml:"tbl"`
+	Chart   *xmlChartRef      `xml:"chart"`
+	Diagram *xmlDiagramRelIds `xml:"relIds"`
+}
+
+type xmlChartRef struct {
+	RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
 }
 
 type xmlTable struct {
+	Grid []xmlGridCol  `xml:"tblGrid>gridCol"`
 	Rows []xmlTableRow `xml:"tr"`
 }
 
+type xmlGridCol struct {
+	W int64 `xml:"w,attr"`
+}
+
 type xmlTableRow struct {
 	Cells []xmlTableCell `xml:"tc"`
 }
 
 type xmlTableCell struct {
-	TxBody *xmlTxBody `xml:"txBody"`
+	GridSpan int        `xml:"gridSpan,attr"`
+	RowSpan  int        `xml:"rowSpan,attr"`
+	HMerge   string     `xml:"hMerge,attr"`
+	VMerge   string     `xml:"vMerge,attr"`
+	TxBody   *xmlTxBody `xml:"txBody"`
 }
 
 type xmlRelationships struct {
@@ -460,7 +1063,8 @@ type xmlRelationships struct {
 }
 
 type xmlRelationship struct {
-	ID     string `xml:"Id,attr"`
-	Target string `xml:"Target,attr"`
-	Type   string `xml:"Type,attr"`
+	ID         string `xml:"Id,attr"`
+	Target     string `xml:"Target,attr"`
+	Type       string `xml:"Type,attr"`
+	TargetMode string `xml:"TargetMode,attr"`
 }
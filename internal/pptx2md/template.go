@@ -0,0 +1,258 @@
+package pptx2md
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// slideTemplate holds the layout and master shapes a slide inherits formatting from.
+type slideTemplate struct {
+	layout   []xmlShape
+	master   []xmlShape
+	txStyles *xmlTxStyles
+}
+
+// loadTemplate parses a slide layout and its master, caching the result by layout path.
+// Missing or malformed parts yield an empty template so slides still convert.
+func loadTemplate(zr *zip.ReadCloser, layoutPath string, cache map[string]*slideTemplate) *slideTemplate {
+	if tmpl, ok := cache[layoutPath]; ok {
+		return tmpl
+	}
+	tmpl := &slideTemplate{}
+	cache[layoutPath] = tmpl
+
+	data, err := readZipFile(zr, layoutPath)
+	if err != nil {
+		return tmpl
+	}
+	var layout xmlSlideLayout
+	if err := xml.Unmarshal(data, &layout); err != nil {
+		return tmpl
+	}
+	tmpl.layout = layout.CSld.SpTree.shapes()
+
+	layoutRels, _ := parseRelList(zr, slideRelsPath(layoutPath))
+	for _, rel := range layoutRels {
+		if !strings.HasSuffix(rel.Type, "/slideMaster") {
+			continue
+		}
+		data, err := readZipFile(zr, resolveRelPath(path.Dir(layoutPath), rel.Target))
+		if err != nil {
+			break
+		}
+		var master xmlSlideMaster
+		if err := xml.Unmarshal(data, &master); err != nil {
+			break
+		}
+		tmpl.master = master.CSld.SpTree.shapes()
+		tmpl.txStyles = master.TxStyles
+		break
+	}
+
+	return tmpl
+}
+
+// bullet resolves the bullet kind of a paragraph. The lookup follows the
+// PowerPoint inheritance chain: the paragraph's own a:pPr, the shape's list
+// style, the matching layout and master placeholders, then the master text
+// styles (bodyStyle for placeholders, otherStyle for free text boxes).
+func (t *slideTemplate) bullet(sp *xmlShape, para xmlParagraph) BulletKind {
+	if kind, ok := para.PPr.bulletKind(); ok {
+		return kind
+	}
+
+	level := para.level()
+	styles := []*xmlListStyle{shapeListStyle(sp)}
+
+	ph := shapePlaceholder(sp)
+	if ph != nil {
+		if layoutSp := findPlaceholder(t.layout, ph); layoutSp != nil {
+			styles = append(styles, shapeListStyle(layoutSp))
+		}
+		if masterSp := findMasterPlaceholder(t.master, ph); masterSp != nil {
+			styles = append(styles, shapeListStyle(masterSp))
+		}
+	}
+	if t.txStyles != nil {
+		switch {
+		case ph == nil:
+			styles = append(styles, t.txStyles.OtherStyle)
+		case isTitleType(t.placeholderType(sp)):
+			styles = append(styles, t.txStyles.TitleStyle)
+		default:
+			styles = append(styles, t.txStyles.BodyStyle)
+		}
+	}
+
+	for _, ls := range styles {
+		if kind, ok := ls.level(level).bulletKind(); ok {
+			return kind
+		}
+	}
+	return BulletNone
+}
+
+// placeholderType resolves a placeholder's type. Slides often identify a
+// placeholder by idx alone, leaving the type to the layout placeholder.
+func (t *slideTemplate) placeholderType(sp *xmlShape) string {
+	ph := shapePlaceholder(sp)
+	if ph == nil {
+		return ""
+	}
+	if ph.Type == "" && ph.Idx != "" {
+		if layoutSp := findPlaceholder(t.layout, ph); layoutSp != nil {
+			return shapePlaceholder(layoutSp).Type
+		}
+	}
+	return ph.Type
+}
+
+// placeholderText returns the text body an empty slide placeholder inherits
+// from its layout placeholder, or nil. Prompt text is not inherited: neither
+// custom prompts nor the "Click to edit Master ..." text a layout copies
+// from the master placeholder.
+func (t *slideTemplate) placeholderText(sp *xmlShape) *xmlTxBody {
+	ph := shapePlaceholder(sp)
+	if ph == nil {
+		return nil
+	}
+	layoutSp := findPlaceholder(t.layout, ph)
+	if layoutSp == nil || !hasText(layoutSp.TxBody) {
+		return nil
+	}
+	layoutPh := shapePlaceholder(layoutSp)
+	if isTrue(layoutPh.HasCustomPrompt) {
+		return nil
+	}
+	if masterSp := findMasterPlaceholder(t.master, layoutPh); masterSp != nil &&
+		txBodyText(masterSp.TxBody) == txBodyText(layoutSp.TxBody) {
+		return nil
+	}
+	return layoutSp.TxBody
+}
+
+func txBodyText(body *xmlTxBody) string {
+	if body == nil {
+		return ""
+	}
+	var lines []string
+	for _, para := range body.Paragraphs {
+		lines = append(lines, paragraphText(para))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bulletKind reports the bullet declared by a paragraph property set, if any.
+func (p *xmlPPr) bulletKind() (BulletKind, bool) {
+	switch {
+	case p == nil:
+		return BulletNone, false
+	case p.BuNone != nil:
+		return BulletNone, true
+	case p.BuAutoNum != nil:
+		return BulletNumber, true
+	case p.BuChar != nil, p.BuBlip != nil:
+		return BulletChar, true
+	default:
+		return BulletNone, false
+	}
+}
+
+// level returns the a:lvlNpPr entry for a zero-based outline level.
+func (ls *xmlListStyle) level(lvl int) *xmlPPr {
+	if ls == nil {
+		return nil
+	}
+	name := fmt.Sprintf("lvl%dpPr", lvl+1)
+	for i := range ls.Levels {
+		if ls.Levels[i].XMLName.Local == name {
+			return &ls.Levels[i]
+		}
+	}
+	return nil
+}
+
+func shapeListStyle(sp *xmlShape) *xmlListStyle {
+	if sp.TxBody == nil {
+		return nil
+	}
+	return sp.TxBody.LstStyle
+}
+
+func shapePlaceholder(sp *xmlShape) *xmlPh {
+	if sp.NvSpPr == nil || sp.NvSpPr.NvPr == nil {
+		return nil
+	}
+	return sp.NvSpPr.NvPr.Ph
+}
+
+// findPlaceholder matches a placeholder on a layout by idx, falling back to type.
+func findPlaceholder(shapes []xmlShape, ph *xmlPh) *xmlShape {
+	if ph.Idx != "" {
+		for i := range shapes {
+			if other := shapePlaceholder(&shapes[i]); other != nil && other.Idx == ph.Idx {
+				return &shapes[i]
+			}
+		}
+	}
+	phType := normalizePhType(ph.Type)
+	for i := range shapes {
+		if other := shapePlaceholder(&shapes[i]); other != nil && normalizePhType(other.Type) == phType {
+			return &shapes[i]
+		}
+	}
+	return nil
+}
+
+// findMasterPlaceholder matches a placeholder on the master, which only
+// carries one placeholder per type; content and subtitle placeholders map to body.
+func findMasterPlaceholder(shapes []xmlShape, ph *xmlPh) *xmlShape {
+	phType := normalizePhType(ph.Type)
+	if phType == "obj" || phType == "subTitle" {
+		phType = "body"
+	}
+	for i := range shapes {
+		if other := shapePlaceholder(&shapes[i]); other != nil && normalizePhType(other.Type) == phType {
+			return &shapes[i]
+		}
+	}
+	return nil
+}
+
+// normalizePhType applies the ph type default ("obj") and folds ctrTitle into title.
+func normalizePhType(phType string) string {
+	switch phType {
+	case "":
+		return "obj"
+	case "ctrTitle":
+		return "title"
+	default:
+		return phType
+	}
+}
+
+func isTitleType(phType string) bool {
+	return phType == "title" || phType == "ctrTitle"
+}
+
+// --- XML structures ---
+
+type xmlSlideLayout struct {
+	XMLName xml.Name `xml:"sldLayout"`
+	CSld    xmlCSld  `xml:"cSld"`
+}
+
+type xmlSlideMaster struct {
+	XMLName  xml.Name     `xml:"sldMaster"`
+	CSld     xmlCSld      `xml:"cSld"`
+	TxStyles *xmlTxStyles `xml:"txStyles"`
+}
+
+type xmlTxStyles struct {
+	TitleStyle *xmlListStyle `xml:"titleStyle"`
+	BodyStyle  *xmlListStyle `xml:"bodyStyle"`
+	OtherStyle *xmlListStyle `xml:"otherStyle"`
+}
@@ -17,19 +17,27 @@ import (
 )
 
 // ConvertOptions holds configuration for pptx to pdf conversion.
-type ConvertOptions struct{}
+type ConvertOptions struct {
+	// IncludeNotes prints each slide's speaker notes below its content.
+	IncludeNotes bool
+	// HiddenSlides controls whether slides hidden in the slide show are converted.
+	HiddenSlides pptx2md.HiddenSlideMode
+}
 
 const (
-	pageW     = 297.0 // A4 landscape width (mm)
-	pageH     = 210.0 // A4 landscape height (mm)
-	margin    = 15.0
-	contentW  = pageW - 2*margin
-	titleSize = 20.0
-	bodySize  = 12.0
-	tableSize = 10.0
-	titleLH   = 8.0 // title line height (mm)
-	bodyLH    = 6.0 // body line height (mm)
-	tableLH   = 5.0 // table cell line height (mm)
+	pageW      = 297.0 // A4 landscape width (mm)
+	pageH      = 210.0 // A4 landscape height (mm)
+	margin     = 15.0
+	contentW   = pageW - 2*margin
+	listIndent = 8.0 // indent per outline level (mm)
+	titleSize  = 20.0
+	bodySize   = 12.0
+	tableSize  = 10.0
+	notesSize  = 10.0
+	titleLH    = 8.0 // title line height (mm)
+	bodyLH     = 6.0 // body line height (mm)
+	tableLH    = 5.0 // table cell line height (mm)
+	notesLH    = 5.0 // notes line height (mm)
 )
 
 // Convert reads a .pptx file and produces a PDF in the same directory.
@@ -53,14 +61,30 @@ func Convert(filePath string, opts ConvertOptions) (string, error) {
 		fontName = "Helvetica"
 	}
 
+	// Slide labels keep the deck's numbering even when hidden slides are left out
 	totalSlides := len(pres.Slides)
-	if totalSlides == 0 {
+	pres.Slides = pres.FilterSlides(opts.HiddenSlides)
+	if len(pres.Slides) == 0 {
 		pdf.AddPage()
 	}
 
+	currentSection := -1
 	for _, slide := range pres.Slides {
 		pdf.AddPage()
-		renderSlide(pdf, pres, slide, fontName, totalSlides)
+
+		// Outline: slides nest under their section when the deck has sections
+		pdf.SetFont(fontName, "", titleSize)
+		level := 0
+		if sec := pres.SectionOf(slide); sec >= 0 {
+			if sec != currentSection {
+				pdf.Bookmark(pres.Sections[sec].Name, 0, 0)
+				currentSection = sec
+			}
+			level = 1
+		}
+		pdf.Bookmark(slideTitle(slide), level, 0)
+
+		renderSlide(pdf, pres, slide, fontName, totalSlides, opts)
 	}
 
 	if pdf.Err() {
@@ -139,7 +163,15 @@ func ensureSpace(pdf *fpdf.Fpdf, y *float64, needed float64) {
 	}
 }
 
-func renderSlide(pdf *fpdf.Fpdf, pres *pptx2md.Presentation, slide *pptx2md.Slide, fontName string, totalSlides int) {
+// slideTitle returns the slide's title, falling back to its number.
+func slideTitle(slide *pptx2md.Slide) string {
+	if slide.Title != "" {
+		return slide.Title
+	}
+	return fmt.Sprintf("Slide %d", slide.Index)
+}
+
+func renderSlide(pdf *fpdf.Fpdf, pres *pptx2md.Presentation, slide *pptx2md.Slide, fontName string, totalSlides int, opts ConvertOptions) {
 	y := margin
 
 	// Slide number (top-right corner)
@@ -149,16 +181,16 @@ func renderSlide(pdf *fpdf.Fpdf, pres *pptx2md.Presentation, slide *pptx2md.Slid
 	labelW := pdf.GetStringWidth(slideLabel)
 	pdf.SetXY(pageW-margin-labelW, margin-3)
 	pdf.CellFormat(labelW, 4, slideLabel, "", 0, "R", false, 0, "")
+	if slide.Hidden && opts.HiddenSlides == pptx2md.HiddenSlidesMark {
+		pdf.SetXY(margin, margin-3)
+		pdf.CellFormat(contentW/2, 4, "Hidden slide", "", 0, "L", false, 0, "")
+	}
 	pdf.SetTextColor(0, 0, 0)
 
 	// Title
-	title := slide.Title
-	if title == "" {
-		title = fmt.Sprintf("Slide %d", slide.Index)
-	}
 	pdf.SetFont(fontName, "", titleSize)
 	pdf.SetXY(margin, y)
-	pdf.MultiCell(contentW, titleLH, title, "", "L", false)
+	pdf.MultiCell(contentW, titleLH, slideTitle(slide), "", "L", false)
 	y = pdf.GetY() + 3
 
 	// Separator line
@@ -166,58 +198,145 @@ func renderSlide(pdf *fpdf.Fpdf, pres *pptx2md.Presentation, slide *pptx2md.Slid
 	pdf.Line(margin, y, margin+contentW, y)
 	y += 4
 
-	// Body text
+	// Shape contents in document order
+	for _, block := range slide.Blocks {
+		switch block.Kind {
+		case pptx2md.BlockText:
+			y = renderParagraphs(pdf, block.Paragraphs, fontName, y)
+		case pptx2md.BlockTable:
+			y = renderTable(pdf, *block.Table, fontName, y)
+		case pptx2md.BlockChart:
+			y = renderChart(pdf, *block.Chart, fontName, y)
+		case pptx2md.BlockDiagram:
+			y = renderParagraphs(pdf, block.Diagram.Paragraphs(), fontName, y)
+		case pptx2md.BlockImage:
+			y = renderImage(pdf, pres, slide, *block.Image, fontName, y)
+		}
+	}
+
+	// Speaker notes
+	if opts.IncludeNotes && len(slide.Notes) > 0 {
+		renderNotes(pdf, slide.Notes, fontName, y)
+	}
+}
+
+func renderParagraphs(pdf *fpdf.Fpdf, paras []pptx2md.Paragraph, fontName string, y float64) float64 {
 	pdf.SetFont(fontName, "", bodySize)
-	for _, body := range slide.Bodies {
+	numbers := make(map[int]int) // running auto-number per outline level
+	for _, para := range paras {
+		// Numbering restarts once a list at this level is interrupted
+		for lvl := range numbers {
+			if lvl > para.Level || (lvl == para.Level && para.Bullet != pptx2md.BulletNumber) {
+				delete(numbers, lvl)
+			}
+		}
+
+		text := para.Text
+		indent := float64(para.Level) * listIndent
+		switch para.Bullet {
+		case pptx2md.BulletChar:
+			text = "- " + text
+		case pptx2md.BulletNumber:
+			numbers[para.Level]++
+			text = fmt.Sprintf("%d. %s", numbers[para.Level], text)
+		}
+
 		ensureSpace(pdf, &y, bodyLH*2)
-		pdf.SetXY(margin, y)
-		pdf.MultiCell(contentW, bodyLH, body, "", "L", false)
+		pdf.SetXY(margin+indent, y)
+		pdf.MultiCell(contentW-indent, bodyLH, text, "", "L", false)
 		y = pdf.GetY() + 2
 	}
+	return y
+}
 
-	// Tables
-	for _, tbl := range slide.Tables {
-		y = renderTable(pdf, tbl, fontName, y)
+// renderImage draws an image scaled to the content width, followed by the
+// caption found next to it on the slide.
+func renderImage(pdf *fpdf.Fpdf, pres *pptx2md.Presentation, slide *pptx2md.Slide, img pptx2md.ImageRef, fontName string, y float64) float64 {
+	if img.MediaPath == "" {
+		return y
+	}
+	imgType := detectImageType(img.MediaPath)
+	if imgType == "" {
+		return y
+	}
+	data, err := pres.ReadMedia(img.MediaPath)
+	if err != nil {
+		return y
 	}
 
-	// Images
-	for _, img := range slide.Images {
-		if img.MediaPath == "" {
-			continue
-		}
-		imgType := detectImageType(img.MediaPath)
-		if imgType == "" {
-			continue
-		}
-		data, err := pres.ReadMedia(img.MediaPath)
-		if err != nil {
-			continue
-		}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return y
+	}
 
-		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
-		if err != nil {
-			continue
-		}
+	// Calculate scaled size before deciding on space
+	captionH := 0.0
+	if img.Caption != "" {
+		captionH = bodyLH
+	}
+	w, h := scaleImage(float64(cfg.Width), float64(cfg.Height), contentW, pageH-2*margin-captionH)
+	ensureSpace(pdf, &y, h+2+captionH)
+
+	// Re-scale for remaining space on current page
+	maxH := pageH - y - margin - captionH
+	if h > maxH {
+		w, h = scaleImage(float64(cfg.Width), float64(cfg.Height), contentW, maxH)
+	}
 
-		// Calculate scaled size before deciding on space
-		w, h := scaleImage(float64(cfg.Width), float64(cfg.Height), contentW, pageH-2*margin)
-		ensureSpace(pdf, &y, h+2)
+	imgName := fmt.Sprintf("s%d_%s", slide.Index, filepath.Base(img.MediaPath))
+	reader := bytes.NewReader(data)
+	imgOpts := fpdf.ImageOptions{ImageType: imgType, ReadDpi: true}
+	pdf.RegisterImageOptionsReader(imgName, imgOpts, reader)
+	if pdf.Ok() {
+		pdf.ImageOptions(imgName, margin, y, w, h, false, imgOpts, 0, "")
+		y += h + 2
+	}
+	if img.Caption != "" {
+		pdf.SetFont(fontName, "", tableSize)
+		pdf.SetTextColor(100, 100, 100)
+		pdf.SetXY(margin, y)
+		pdf.CellFormat(contentW, bodyLH, img.Caption, "", 0, "L", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		y += captionH
+	}
+	return y
+}
 
-		// Re-scale for remaining space on current page
-		maxH := pageH - y - margin
-		if h > maxH {
-			w, h = scaleImage(float64(cfg.Width), float64(cfg.Height), contentW, maxH)
-		}
+func renderNotes(pdf *fpdf.Fpdf, notes []string, fontName string, y float64) float64 {
+	ensureSpace(pdf, &y, notesLH*3)
+	y += 2
 
-		imgName := fmt.Sprintf("s%d_%s", slide.Index, filepath.Base(img.MediaPath))
-		reader := bytes.NewReader(data)
-		imgOpts := fpdf.ImageOptions{ImageType: imgType, ReadDpi: true}
-		pdf.RegisterImageOptionsReader(imgName, imgOpts, reader)
-		if pdf.Ok() {
-			pdf.ImageOptions(imgName, margin, y, w, h, false, imgOpts, 0, "")
-			y += h + 2
-		}
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Line(margin, y, margin+contentW, y)
+	y += 2
+
+	pdf.SetFont(fontName, "", notesSize)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.SetXY(margin, y)
+	pdf.CellFormat(contentW, notesLH, "Notes", "", 0, "L", false, 0, "")
+	y += notesLH + 1
+
+	for _, para := range notes {
+		ensureSpace(pdf, &y, notesLH*2)
+		pdf.SetXY(margin, y)
+		pdf.MultiCell(contentW, notesLH, para, "", "L", false)
+		y = pdf.GetY() + 1
 	}
+	pdf.SetTextColor(0, 0, 0)
+
+	return y
+}
+
+// renderChart draws a caption naming the chart, followed by its data table.
+func renderChart(pdf *fpdf.Fpdf, chart pptx2md.Chart, fontName string, y float64) float64 {
+	ensureSpace(pdf, &y, bodyLH+tableLH*3)
+	pdf.SetFont(fontName, "", tableSize)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.SetXY(margin, y)
+	pdf.CellFormat(contentW, bodyLH, chart.Caption(), "", 0, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	return renderTable(pdf, chart.Table(), fontName, y+bodyLH)
 }
 
 func renderTable(pdf *fpdf.Fpdf, tbl pptx2md.Table, fontName string, y float64) float64 {
@@ -238,55 +357,120 @@ func renderTable(pdf *fpdf.Fpdf, tbl pptx2md.Table, fontName string, y float64)
 		return y
 	}
 
-	colW := contentW / float64(numCols)
+	colW := tableColumnWidths(tbl.ColWidths, numCols)
+	spanOf := func(r, c int) pptx2md.CellSpan {
+		if r < len(tbl.Spans) && c < len(tbl.Spans[r]) {
+			return tbl.Spans[r][c]
+		}
+		return pptx2md.CellSpan{ColSpan: 1, RowSpan: 1}
+	}
+	// spanWidth sums the widths of the grid columns a cell covers
+	spanWidth := func(c, span int) float64 {
+		w := 0.0
+		for i := c; i < c+span && i < numCols; i++ {
+			w += colW[i]
+		}
+		return w
+	}
+
 	pdf.SetFont(fontName, "", tableSize)
 	pdf.SetDrawColor(180, 180, 180)
 
+	// Row heights fit the tallest cell; a cell spanning rows grows the last row it covers
+	rowH := make([]float64, len(tbl.Rows))
+	for i := range rowH {
+		rowH[i] = tableLH
+	}
 	for rowIdx, row := range tbl.Rows {
-		// Calculate row height based on tallest cell
-		rowH := tableLH
-		for _, cell := range row {
-			lines := pdf.SplitText(cell, colW-2)
-			cellH := float64(len(lines)) * tableLH
-			if cellH > rowH {
-				rowH = cellH
+		for colIdx, cell := range row {
+			span := spanOf(rowIdx, colIdx)
+			if span.Covered {
+				continue
 			}
+			lastRow := min(rowIdx+span.RowSpan, len(tbl.Rows)) - 1
+			lines := pdf.SplitText(cell, spanWidth(colIdx, span.ColSpan)-2)
+			need := float64(len(lines)) * tableLH
+			for r := rowIdx; r < lastRow; r++ {
+				need -= rowH[r]
+			}
+			rowH[lastRow] = max(rowH[lastRow], need)
 		}
+	}
 
-		ensureSpace(pdf, &y, rowH+1)
+	for rowIdx, row := range tbl.Rows {
+		// Keep cells that span rows on a single page
+		blockH := rowH[rowIdx]
+		for colIdx := range row {
+			if span := spanOf(rowIdx, colIdx); !span.Covered && span.RowSpan > 1 {
+				h := 0.0
+				for r := rowIdx; r < rowIdx+span.RowSpan && r < len(rowH); r++ {
+					h += rowH[r]
+				}
+				blockH = max(blockH, h)
+			}
+		}
+		ensureSpace(pdf, &y, blockH+1)
 
 		// Header row: light gray background
 		if rowIdx == 0 {
 			pdf.SetFillColor(240, 240, 240)
 		}
 
+		x := margin
 		for colIdx := 0; colIdx < numCols; colIdx++ {
-			x := margin + float64(colIdx)*colW
+			cellX := x
+			x += colW[colIdx]
+			span := spanOf(rowIdx, colIdx)
+			if span.Covered {
+				continue
+			}
 			cellText := ""
 			if colIdx < len(row) {
 				cellText = row[colIdx]
 			}
+			w := spanWidth(colIdx, span.ColSpan)
+			h := 0.0
+			for r := rowIdx; r < rowIdx+span.RowSpan && r < len(rowH); r++ {
+				h += rowH[r]
+			}
 
 			// Draw cell border
-			pdf.Rect(x, y, colW, rowH, "D")
 			if rowIdx == 0 {
-				pdf.Rect(x, y, colW, rowH, "F")
-				pdf.Rect(x, y, colW, rowH, "D")
+				pdf.Rect(cellX, y, w, h, "F")
 			}
+			pdf.Rect(cellX, y, w, h, "D")
 
 			// Draw text inside cell
-			lines := pdf.SplitText(cellText, colW-2)
+			lines := pdf.SplitText(cellText, w-2)
 			for lineIdx, line := range lines {
-				pdf.SetXY(x+1, y+float64(lineIdx)*tableLH)
-				pdf.CellFormat(colW-2, tableLH, line, "", 0, "L", false, 0, "")
+				pdf.SetXY(cellX+1, y+float64(lineIdx)*tableLH)
+				pdf.CellFormat(w-2, tableLH, line, "", 0, "L", false, 0, "")
 			}
 		}
-		y += rowH
+		y += rowH[rowIdx]
 	}
 
 	return y + 3
 }
 
+// tableColumnWidths scales the a:tblGrid column widths to the content width,
+// falling back to equal columns when the grid does not match the table.
+func tableColumnWidths(grid []int64, numCols int) []float64 {
+	var total int64
+	for _, w := range grid {
+		total += w
+	}
+	colW := make([]float64, numCols)
+	for i := range colW {
+		if len(grid) == numCols && total > 0 {
+			colW[i] = contentW * float64(grid[i]) / float64(total)
+		} else {
+			colW[i] = contentW / float64(numCols)
+		}
+	}
+	return colW
+}
+
 func detectImageType(mediaPath string) string {
 	switch strings.ToLower(filepath.Ext(mediaPath)) {
 	case ".png":
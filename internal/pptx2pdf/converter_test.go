@@ -1,10 +1,15 @@
 package pptx2pdf
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"ar-tools/internal/pptx2md"
+
+	"github.com/go-pdf/fpdf"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,9 +37,84 @@ func TestConvert_SamplePptx(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, len(pdfData) >= 5)
 	assert.Equal(t, "%PDF-", string(pdfData[:5]))
+	assert.Contains(t, string(pdfData), "/Outlines", "slides should be bookmarked")
+}
+
+func TestRenderSlide_Notes(t *testing.T) {
+	// Enough notes paragraphs to run past the end of the slide's page
+	slide := &pptx2md.Slide{Index: 1, Title: "Agenda"}
+	for i := range 40 {
+		slide.Notes = append(slide.Notes, fmt.Sprintf("Talking point %d", i+1))
+	}
+
+	pdf := newTestPDF()
+	renderSlide(pdf, &pptx2md.Presentation{}, slide, "Helvetica", 1, ConvertOptions{})
+	assert.Equal(t, 1, pdf.PageCount())
+	assert.NotContains(t, pdfContent(t, pdf), "Talking point")
+
+	pdf = newTestPDF()
+	renderSlide(pdf, &pptx2md.Presentation{}, slide, "Helvetica", 1, ConvertOptions{IncludeNotes: true})
+	assert.Equal(t, 2, pdf.PageCount(), "notes should continue on a new page")
+	content := pdfContent(t, pdf)
+	assert.Contains(t, content, "(Notes)Tj")
+	assert.Contains(t, content, "(Talking point 40)Tj")
+}
+
+func TestRenderSlide_HiddenMark(t *testing.T) {
+	// Which slides are converted is decided by pptx2md.FilterSlides
+	slide := &pptx2md.Slide{Index: 2, Title: "Backup", Hidden: true}
+
+	pdf := newTestPDF()
+	renderSlide(pdf, &pptx2md.Presentation{}, slide, "Helvetica", 3, ConvertOptions{HiddenSlides: pptx2md.HiddenSlidesMark})
+	content := pdfContent(t, pdf)
+	assert.Contains(t, content, "(Hidden slide)Tj")
+	assert.Contains(t, content, "(2 / 3)Tj")
+
+	pdf = newTestPDF()
+	renderSlide(pdf, &pptx2md.Presentation{}, slide, "Helvetica", 3, ConvertOptions{})
+	assert.NotContains(t, pdfContent(t, pdf), "Hidden slide")
+}
+
+func TestRenderTable_MergedCells(t *testing.T) {
+	assert.Equal(t, []float64{contentW / 4, contentW * 3 / 4}, tableColumnWidths([]int64{1000, 3000}, 2))
+	assert.Equal(t, []float64{contentW / 2, contentW / 2}, tableColumnWidths([]int64{1000}, 2))
+
+	one := pptx2md.CellSpan{ColSpan: 1, RowSpan: 1}
+	tbl := pptx2md.Table{
+		Rows: [][]string{{"Region", "Sales", ""}, {"North", "Q1", "10"}, {"", "Q2", "12"}},
+		Spans: [][]pptx2md.CellSpan{
+			{one, {ColSpan: 2, RowSpan: 1}, {ColSpan: 1, RowSpan: 1, Covered: true}},
+			{{ColSpan: 1, RowSpan: 2}, one, one},
+			{{ColSpan: 1, RowSpan: 1, Covered: true}, one, one},
+		},
+		ColWidths: []int64{1000000, 2000000, 3000000},
+	}
+
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.AddPage()
+	y := renderTable(pdf, tbl, "Helvetica", margin)
+	assert.NoError(t, pdf.Error())
+	assert.InDelta(t, margin+2+3*tableLH+3, y, 0.001)
 }
 
 func TestConvert_FileNotFound(t *testing.T) {
 	_, err := Convert("nonexistent.pptx", ConvertOptions{})
 	assert.Error(t, err)
 }
+
+// newTestPDF returns an uncompressed PDF with one page, so drawn text can be
+// found in its output.
+func newTestPDF() *fpdf.Fpdf {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetCompression(false)
+	pdf.SetAutoPageBreak(false, margin)
+	pdf.AddPage()
+	return pdf
+}
+
+func pdfContent(t *testing.T, pdf *fpdf.Fpdf) string {
+	t.Helper()
+	var buf bytes.Buffer
+	assert.NoError(t, pdf.Output(&buf))
+	return buf.String()
+}
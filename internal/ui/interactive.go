@@ -150,6 +150,9 @@ func convertPptxFiles(files []string) error {
 		if result.ImageDir != "" {
 			fmt.Printf("  📁 圖片: %s\n", result.ImageDir)
 		}
+		for _, m := range result.MissingAltText {
+			fmt.Printf("  ⚠ 缺少替代文字: 第 %d 張投影片 %s (%s)\n", m.Slide, m.Name, filepath.Base(m.MediaPath))
+		}
 		succeeded++
 	}
 
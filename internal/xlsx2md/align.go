@@ -0,0 +1,100 @@
+package xlsx2md
+
+import (
+	"ar-tools/internal/mdtable"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// readAlignments returns a grid matching rows with each non-empty cell's
+// displayed horizontal alignment ("left", "center" or "right"): the cell
+// style's alignment when set, otherwise Excel's general alignment for its
+// type (numbers and dates right, booleans and errors center, text left).
+func readAlignments(f *excelize.File, sheet string, rows [][]string) ([][]string, error) {
+	styleAligns := make(map[int]string) // style id -> horizontal alignment
+	aligns := make([][]string, len(rows))
+	for i, row := range rows {
+		aligns[i] = make([]string, len(row))
+		for j, value := range row {
+			if value == "" {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
+			if err != nil {
+				return nil, err
+			}
+			styleID, err := f.GetCellStyle(sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			align, ok := styleAligns[styleID]
+			if !ok {
+				if style, err := f.GetStyle(styleID); err == nil && style.Alignment != nil {
+					align = horizontalAlign(style.Alignment.Horizontal)
+				}
+				styleAligns[styleID] = align
+			}
+			if align == "" {
+				cellType, err := f.GetCellType(sheet, cell)
+				if err != nil {
+					return nil, err
+				}
+				align = generalAlign(cellType)
+			}
+			aligns[i][j] = align
+		}
+	}
+	return aligns, nil
+}
+
+// horizontalAlign maps a style's horizontal alignment to left, center or
+// right, or "" for general alignment.
+func horizontalAlign(horizontal string) string {
+	switch horizontal {
+	case "left", "right", "center":
+		return horizontal
+	case "centerContinuous":
+		return "center"
+	case "fill", "justify", "distributed":
+		return "left"
+	default:
+		return ""
+	}
+}
+
+// generalAlign returns how Excel aligns a cell of the given type by default.
+func generalAlign(cellType excelize.CellType) string {
+	switch cellType {
+	case excelize.CellTypeSharedString, excelize.CellTypeInlineString, excelize.CellTypeFormula:
+		// Formula cells report this type only for string results
+		return "left"
+	case excelize.CellTypeBool, excelize.CellTypeError:
+		return "center"
+	default:
+		return "right"
+	}
+}
+
+// columnAlignments picks each column's most common alignment among the
+// cells below the header row. Ties go to left, then right.
+func columnAlignments(aligns [][]string, cols int) []mdtable.Alignment {
+	out := make([]mdtable.Alignment, cols)
+	for j := range out {
+		counts := make(map[string]int)
+		for _, row := range aligns[min(1, len(aligns)):] {
+			if j < len(row) && row[j] != "" {
+				counts[row[j]]++
+			}
+		}
+		best := 0
+		for _, c := range []struct {
+			name  string
+			align mdtable.Alignment
+		}{{"left", mdtable.AlignLeft}, {"right", mdtable.AlignRight}, {"center", mdtable.AlignCenter}} {
+			if counts[c.name] > best {
+				best, out[j] = counts[c.name], c.align
+			}
+		}
+	}
+	return out
+}
@@ -1,9 +1,15 @@
 package xlsx2md
 
 import (
+	"archive/zip"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 
+	"ar-tools/internal/docprops"
+	"ar-tools/internal/mdtable"
+
 	"github.com/xuri/excelize/v2"
 )
 
@@ -11,37 +17,154 @@ import (
 type ConvertOptions struct {
 	// SheetNames specifies which sheets to convert. Empty means all sheets.
 	SheetNames []string
+	// Ranges selects blocks to convert instead of whole sheets: A1 references
+	// ("Summary!B3:H40"), defined names or Excel table names, each rendered as
+	// its own section. Unqualified references use the first sheet in SheetNames.
+	Ranges []string
+	// Frontmatter prepends YAML frontmatter built from the document properties.
+	Frontmatter bool
+	// MergedCells controls how merged cell ranges are rendered. Default leaves covered cells blank.
+	MergedCells mdtable.MergedCellMode
+	// Values selects formatted, raw or ISO 8601 normalized cell values. Default is formatted.
+	Values ValueMode
+	// Formulas controls whether cell formulas are written. Default writes results only.
+	Formulas FormulaMode
+	// Tables controls whether Excel tables on a sheet are converted on their own. Default ignores them.
+	Tables TableMode
+	// Layout trims empty edges, splits blank-row-separated blocks and picks
+	// the header row. Default treats the first row as the header. Excel tables
+	// use their declared header instead.
+	Layout LayoutOptions
+	// HiddenSheets, HiddenRows and HiddenColumns control how content hidden
+	// in Excel is converted. Default converts it like visible content.
+	// HiddenSheets applies to whole sheets, not to explicitly selected Ranges.
+	HiddenSheets  HiddenMode
+	HiddenRows    HiddenMode
+	HiddenColumns HiddenMode
+	// Escape controls how Markdown-significant characters in cells are
+	// written. Default escapes them where they would change formatting.
+	Escape mdtable.EscapeMode
+	// AlignColumns sets each column's alignment from its cells' horizontal
+	// alignment style, or from their data type: numbers right, text left,
+	// booleans center. Default leaves columns unaligned.
+	AlignColumns bool
+	// TableStyle controls how the Markdown source of tables is laid out. Default is compact.
+	TableStyle mdtable.TableStyle
+	// RichText writes bold, italic and strikethrough runs as Markdown emphasis
+	// and hyperlinks as links. Links to another sheet ("Sheet2!A1") point at
+	// that sheet's heading. HTML tables keep plain text.
+	RichText bool
 }
 
 // Convert reads an Excel file and returns its content as Markdown tables.
-// Each sheet is rendered as a separate section with a heading.
+// Each sheet, or each selected range, is rendered as a separate section with a heading.
 func Convert(filePath string, opts ConvertOptions) (string, error) {
-	f, err := excelize.OpenFile(filePath)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open excel file: %w", err)
+	}
+	defer file.Close()
+	f, err := excelize.OpenReader(file)
 	if err != nil {
 		return "", fmt.Errorf("failed to open excel file: %w", err)
 	}
 	defer f.Close()
+	// Table parts and document properties are read through the same handle
+	info, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to open excel file: %w", err)
+	}
+	zr, err := zip.NewReader(file, info.Size())
+	if err != nil {
+		return "", fmt.Errorf("failed to open excel file: %w", err)
+	}
 
 	sheets := opts.SheetNames
 	if len(sheets) == 0 {
 		sheets = f.GetSheetList()
 	}
 
+	tables, err := readExcelTables(zr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read excel tables: %w", err)
+	}
+	var ranges []sheetRange
+	if len(opts.Ranges) > 0 {
+		defaultSheet := ""
+		if len(sheets) > 0 {
+			defaultSheet = sheets[0]
+		}
+		for _, ref := range opts.Ranges {
+			rng, err := resolveRange(f, ref, defaultSheet, tables)
+			if err != nil {
+				return "", err
+			}
+			ranges = append(ranges, rng)
+		}
+	} else {
+		for _, sheet := range sheets {
+			visible, err := f.GetSheetVisible(sheet)
+			if err != nil {
+				return "", fmt.Errorf("failed to read visibility of sheet %q: %w", sheet, err)
+			}
+			hidden := !visible && opts.HiddenSheets == HiddenMark
+			if !visible && opts.HiddenSheets == HiddenExclude {
+				continue
+			}
+			if opts.Tables == TablesIgnore {
+				rng := wholeSheet(sheet)
+				rng.Hidden = hidden
+				ranges = append(ranges, rng)
+				continue
+			}
+			sheetTbls, err := sheetTables(f, sheet, tables)
+			if err != nil {
+				return "", fmt.Errorf("failed to read tables of sheet %q: %w", sheet, err)
+			}
+			for i := range sheetTbls {
+				sheetTbls[i].Hidden = hidden
+			}
+			// Cells around the tables keep the sheet's name; TablesOnly drops them
+			if opts.Tables == TablesDetect {
+				rng := wholeSheet(sheet)
+				rng.Exclude = sheetTbls
+				rng.Hidden = hidden
+				ranges = append(ranges, rng)
+			}
+			ranges = append(ranges, sheetTbls...)
+		}
+	}
+
 	var sb strings.Builder
-	for i, sheet := range sheets {
-		rows, err := f.GetRows(sheet)
+	if opts.Frontmatter {
+		meta, err := docprops.ReadZip(zr)
 		if err != nil {
-			return "", fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+			return "", err
 		}
-		if len(rows) == 0 {
-			continue
+		sb.WriteString(meta.Frontmatter())
+	}
+
+	contents := make([]*rangeContent, len(ranges))
+	for i, rng := range ranges {
+		if contents[i], err = readRange(f, rng, opts); err != nil {
+			return "", err
 		}
+	}
+	// Links into the workbook can only point at sheets that get a heading
+	var anchors map[string]string
+	if opts.RichText {
+		anchors = sectionAnchors(contents, opts)
+	}
 
+	for i, content := range contents {
+		section := content.markdown(opts, anchors)
+		if section == "" {
+			continue
+		}
 		if i > 0 {
 			sb.WriteString("\n")
 		}
-		sb.WriteString(fmt.Sprintf("## %s\n\n", sheet))
-		sb.WriteString(sheetToMarkdown(rows))
+		sb.WriteString(section)
 	}
 
 	return sb.String(), nil
@@ -49,62 +172,240 @@ func Convert(filePath string, opts ConvertOptions) (string, error) {
 
 // ConvertSheet converts a single sheet's rows into a Markdown table string.
 func ConvertSheet(rows [][]string) string {
-	return sheetToMarkdown(rows)
+	return mdtable.RenderTable(rows, nil, mdtable.TableOptions{})
+}
+
+// rangeContent is a sheet or range read and laid out as tables, ready to be
+// written once the section anchors of the workbook are known.
+type rangeContent struct {
+	rng        sheetRange
+	blocks     []tableBlock
+	aligns     [][]string // cell alignments of the grid the blocks were cut from
+	formulas   []cellFormula
+	htmlTables bool
+	rich       []richCell // cells written as rich Markdown, referenced by placeholder
 }
 
-func sheetToMarkdown(rows [][]string) string {
+// readRange reads one sheet or range and lays it out as tables. Rich text
+// cells are left as placeholders, since their links may point at sections
+// written later.
+func readRange(f *excelize.File, rng sheetRange, opts ConvertOptions) (*rangeContent, error) {
+	sheet := rng.Sheet
+	content := &rangeContent{rng: rng}
+	rows, err := readRows(f, sheet, opts.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+	}
+	// aligns mirrors rows through every step that moves cells
+	var aligns [][]string
+	if opts.AlignColumns {
+		if aligns, err = readAlignments(f, sheet, rows); err != nil {
+			return nil, fmt.Errorf("failed to read cell alignment of sheet %q: %w", sheet, err)
+		}
+	}
+	var rich map[[2]int]richCell
+	if opts.RichText {
+		if rich, err = richCells(f, sheet, rows, opts.Escape); err != nil {
+			return nil, fmt.Errorf("failed to read rich text of sheet %q: %w", sheet, err)
+		}
+	}
+	var merges []mdtable.MergedRange
+	if opts.MergedCells != mdtable.MergedCellsBlank {
+		if merges, err = sheetMerges(f, sheet); err != nil {
+			return nil, fmt.Errorf("failed to read merged cells of sheet %q: %w", sheet, err)
+		}
+	}
+	if len(rng.Exclude) > 0 {
+		rows = blankRanges(rows, rng.Exclude)
+		aligns = blankRanges(aligns, rng.Exclude)
+		merges = slices.DeleteFunc(merges, func(m mdtable.MergedRange) bool {
+			return rng.excludes(m.Row, m.Col)
+		})
+	}
+	aligns, _ = rng.crop(aligns, merges)
+	rows, merges = rng.crop(rows, merges)
 	if len(rows) == 0 {
-		return ""
+		return content, nil
 	}
 
-	// Determine max column count across all rows
-	maxCols := 0
-	for _, row := range rows {
-		if len(row) > maxCols {
-			maxCols = len(row)
+	// A range with merges in HTML mode is written as HTML tables, which
+	// escape their cells as HTML instead
+	htmlTables := opts.MergedCells == mdtable.MergedCellsHTML && len(merges) > 0
+	escape := opts.Escape
+	if htmlTables {
+		escape = mdtable.EscapePreserve
+	}
+	for i, row := range rows {
+		rows[i] = escapeRow(row, escape)
+		for j, cell := range rows[i] {
+			if rc, ok := rich[[2]int{rng.FromRow + i, rng.FromCol + j}]; ok && cell != "" && !htmlTables {
+				rows[i][j] = richPlaceholder(len(content.rich))
+				content.rich = append(content.rich, rc)
+			}
 		}
 	}
-	if maxCols == 0 {
-		return ""
+
+	var formulas []cellFormula
+	if opts.Formulas != FormulasOff {
+		if formulas, err = sheetFormulas(f, sheet, rows, rng.FromRow, rng.FromCol); err != nil {
+			return nil, fmt.Errorf("failed to read formulas of sheet %q: %w", sheet, err)
+		}
+		formulas = slices.DeleteFunc(formulas, func(fc cellFormula) bool {
+			return rng.excludes(rng.FromRow+fc.row, rng.FromCol+fc.col)
+		})
+		if opts.Formulas != FormulasTable {
+			rows = withFormulas(rows, formulas, opts.Formulas, htmlTables)
+		}
 	}
 
-	var sb strings.Builder
+	if opts.HiddenRows != HiddenInclude || opts.HiddenColumns != HiddenInclude {
+		hiddenRows, hiddenCols, err := sheetHidden(f, sheet, rows, rng.FromRow, rng.FromCol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hidden rows and columns of sheet %q: %w", sheet, err)
+		}
+		var dropRows, dropCols, markRows, markCols []bool
+		switch opts.HiddenRows {
+		case HiddenExclude:
+			dropRows = hiddenRows
+		case HiddenMark:
+			markRows = hiddenRows
+		}
+		switch opts.HiddenColumns {
+		case HiddenExclude:
+			dropCols = hiddenCols
+		case HiddenMark:
+			markCols = hiddenCols
+		}
+		formulas = slices.DeleteFunc(formulas, func(fc cellFormula) bool {
+			return fc.row < len(dropRows) && dropRows[fc.row] || fc.col < len(dropCols) && dropCols[fc.col]
+		})
+		aligns, _ = dropHidden(aligns, merges, dropRows, dropCols)
+		rows, merges = dropHidden(rows, merges, dropRows, dropCols)
+		marker := hiddenMarker
+		if htmlTables {
+			marker = hiddenMarkerHTML
+		}
+		rows = markHidden(rows, markRows, markCols, marker)
+	}
 
-	// Header row
-	header := padRow(rows[0], maxCols)
-	sb.WriteString("| " + strings.Join(escapeCells(header), " | ") + " |\n")
+	if rng.Table != nil {
+		var inserted int
+		rows, inserted = rng.Table.apply(rows, htmlTables)
+		for i := range inserted {
+			rows[i] = escapeRow(rows[i], escape)
+		}
+		aligns = append(make([][]string, inserted), aligns...)
+		for i := range merges {
+			merges[i].Row += inserted
+		}
+	}
 
-	// Separator row
-	seps := make([]string, maxCols)
-	for i := range seps {
-		seps[i] = "---"
+	blocks := []tableBlock{{Rows: rows, Merges: merges, Area: sheetRange{ToRow: -1, ToCol: -1}}}
+	if rng.Table == nil {
+		blocks = opts.Layout.blocks(rows, merges)
 	}
-	sb.WriteString("| " + strings.Join(seps, " | ") + " |\n")
+	content.blocks, content.aligns = blocks, aligns
+	content.formulas, content.htmlTables = formulas, htmlTables
+	return content, nil
+}
 
-	// Data rows
-	for _, row := range rows[1:] {
-		padded := padRow(row, maxCols)
-		sb.WriteString("| " + strings.Join(escapeCells(padded), " | ") + " |\n")
+// markdown renders the content as a "## title" section, or "" when it holds
+// no cells. anchors maps lower-cased sheet names to the anchors of their
+// sections.
+func (c *rangeContent) markdown(opts ConvertOptions, anchors map[string]string) string {
+	if c.empty() {
+		return ""
+	}
+	var body []string
+	for _, block := range c.blocks {
+		var text strings.Builder
+		for _, line := range block.Preamble {
+			text.WriteString(c.resolve(line, anchors) + "\n\n")
+		}
+		var colAligns []mdtable.Alignment
+		if opts.AlignColumns {
+			blockAligns, _ := block.Area.crop(c.aligns, nil)
+			cols := 0
+			for _, row := range block.Rows {
+				cols = max(cols, len(row))
+			}
+			colAligns = columnAlignments(blockAligns, cols)
+		}
+		rows := block.Rows
+		if len(c.rich) > 0 {
+			rows = make([][]string, len(block.Rows))
+			for i, row := range block.Rows {
+				rows[i] = make([]string, len(row))
+				for j, cell := range row {
+					rows[i][j] = c.resolve(cell, anchors)
+				}
+			}
+		}
+		if c.htmlTables {
+			text.WriteString(mdtable.HTMLTable(rows, block.Merges))
+		} else {
+			text.WriteString(mdtable.RenderTable(rows, block.Merges, mdtable.TableOptions{
+				MergedCells: opts.MergedCells,
+				Style:       opts.TableStyle,
+				Aligns:      colAligns,
+			}))
+		}
+		if text.Len() > 0 {
+			body = append(body, strings.TrimRight(text.String(), "\n")+"\n")
+		}
 	}
 
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## %s\n\n", c.rng.Title))
+	if c.rng.Hidden {
+		sb.WriteString("*(Hidden sheet)*\n\n")
+	}
+	sb.WriteString(strings.Join(body, "\n"))
+	if opts.Formulas == FormulasTable && len(c.formulas) > 0 {
+		sb.WriteString("\n### Formulas\n\n")
+		sb.WriteString(formulasToMarkdown(c.formulas, opts.TableStyle))
+	}
 	return sb.String()
 }
 
-// padRow ensures the row has exactly n columns, padding with empty strings.
-func padRow(row []string, n int) []string {
-	if len(row) >= n {
-		return row[:n]
+// empty reports whether the content has no cells to write, so it gets no section.
+func (c *rangeContent) empty() bool {
+	for _, block := range c.blocks {
+		if len(block.Preamble) > 0 {
+			return false
+		}
+		for _, row := range block.Rows {
+			if len(row) > 0 {
+				return false
+			}
+		}
 	}
-	padded := make([]string, n)
-	copy(padded, row)
-	return padded
+	return true
 }
 
-// escapeCells escapes pipe characters in cell values to avoid breaking Markdown tables.
-func escapeCells(cells []string) []string {
-	out := make([]string, len(cells))
-	for i, c := range cells {
-		out[i] = strings.ReplaceAll(c, "|", "\\|")
+// sheetMerges reads a sheet's merged cell ranges as zero-based row/column
+// ranges matching the rows returned by GetRows.
+func sheetMerges(f *excelize.File, sheet string) ([]mdtable.MergedRange, error) {
+	mergeCells, err := f.GetMergeCells(sheet, true)
+	if err != nil {
+		return nil, err
+	}
+	var merges []mdtable.MergedRange
+	for _, mc := range mergeCells {
+		startCol, startRow, err := excelize.CellNameToCoordinates(mc.GetStartAxis())
+		if err != nil {
+			return nil, err
+		}
+		endCol, endRow, err := excelize.CellNameToCoordinates(mc.GetEndAxis())
+		if err != nil {
+			return nil, err
+		}
+		merges = append(merges, mdtable.MergedRange{
+			Row:  startRow - 1,
+			Col:  startCol - 1,
+			Rows: endRow - startRow + 1,
+			Cols: endCol - startCol + 1,
+		})
 	}
-	return out
+	return merges, nil
 }
@@ -3,7 +3,11 @@ package xlsx2md
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"ar-tools/internal/mdtable"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/xuri/excelize/v2"
@@ -118,6 +122,575 @@ func TestConvert(t *testing.T) {
 	})
 }
 
+func TestConvert_Frontmatter(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetCellValue("Sheet1", "A1", "Name")
+	assert.NoError(t, f.SetDocProps(&excelize.DocProperties{
+		Title:    "Scores",
+		Creator:  "Alice",
+		Keywords: "grades",
+		Created:  "2024-01-02T03:04:05Z",
+	}))
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	result, err := Convert(tmpFile, ConvertOptions{Frontmatter: true})
+	assert.NoError(t, err)
+	assert.Contains(t, result, "---\ntitle: \"Scores\"\nauthor: \"Alice\"\nkeywords:\n  - \"grades\"\ncreated: 2024-01-02T03:04:05Z\n")
+	assert.Contains(t, result, "---\n\n## Sheet1\n")
+}
+
+func TestConvert_MergedCells(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetCellValue("Sheet1", "A1", "Region")
+	f.SetCellValue("Sheet1", "B1", "Sales")
+	f.SetCellValue("Sheet1", "A2", "North")
+	f.SetCellValue("Sheet1", "B2", "Q1")
+	f.SetCellValue("Sheet1", "C2", "10")
+	f.SetCellValue("Sheet1", "B3", "Q2")
+	f.SetCellValue("Sheet1", "C3", "12")
+	assert.NoError(t, f.MergeCell("Sheet1", "B1", "C1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "A3"))
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	blank, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, blank, "| Region | Sales |  |\n| --- | --- | --- |\n| North | Q1 | 10 |\n|  | Q2 | 12 |\n")
+
+	repeat, err := Convert(tmpFile, ConvertOptions{MergedCells: mdtable.MergedCellsRepeat})
+	assert.NoError(t, err)
+	assert.Contains(t, repeat, "| Region | Sales | Sales |\n| --- | --- | --- |\n| North | Q1 | 10 |\n| North | Q2 | 12 |\n")
+
+	html, err := Convert(tmpFile, ConvertOptions{MergedCells: mdtable.MergedCellsHTML})
+	assert.NoError(t, err)
+	assert.Contains(t, html, "## Sheet1\n\n<table>\n"+
+		"  <tr><th>Region</th><th colspan=\"2\">Sales</th></tr>\n"+
+		"  <tr><td rowspan=\"2\">North</td><td>Q1</td><td>10</td></tr>\n"+
+		"  <tr><td>Q2</td><td>12</td></tr>\n"+
+		"</table>\n")
+}
+
+func TestConvert_ValueModes(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	date1904 := true
+	assert.NoError(t, f.SetWorkbookProps(&excelize.WorkbookPropsOptions{Date1904: &date1904}))
+
+	dateFmt := "yyyy/mm/dd"
+	dateStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateFmt})
+	assert.NoError(t, err)
+	timeStyle, err := f.NewStyle(&excelize.Style{NumFmt: 21}) // h:mm:ss
+	assert.NoError(t, err)
+	numStyle, err := f.NewStyle(&excelize.Style{NumFmt: 4}) // #,##0.00
+	assert.NoError(t, err)
+
+	f.SetSheetRow("Sheet1", "A1", &[]any{"Date", "Time", "Amount", "Code"})
+	f.SetCellValue("Sheet1", "A2", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+	f.SetCellValue("Sheet1", "B2", 0.5)
+	f.SetCellValue("Sheet1", "C2", 1234.5)
+	f.SetCellValue("Sheet1", "D2", "007")
+	f.SetCellStyle("Sheet1", "A2", "A2", dateStyle)
+	f.SetCellStyle("Sheet1", "B2", "B2", timeStyle)
+	f.SetCellStyle("Sheet1", "C2", "C2", numStyle)
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	formatted, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, formatted, "| 2024/03/15 | 12:00:00 | 1,234.50 | 007 |")
+
+	raw, err := Convert(tmpFile, ConvertOptions{Values: ValuesRaw})
+	assert.NoError(t, err)
+	assert.Contains(t, raw, "| 43904 | 0.5 | 1234.5 | 007 |") // 1904 date system serial
+
+	iso, err := Convert(tmpFile, ConvertOptions{Values: ValuesISO})
+	assert.NoError(t, err)
+	assert.Contains(t, iso, "| 2024-03-15 | 12:00:00 | 1234.5 | 007 |")
+}
+
+func TestConvert_Formulas(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetRow("Sheet1", "A1", &[]any{"Item", "Qty", "Price", "Total"})
+	f.SetSheetRow("Sheet1", "A2", &[]any{"Pen", 2, 3})
+	f.SetSheetRow("Sheet1", "A3", &[]any{"Ink", 1, 5})
+	f.SetSheetRow("Sheet1", "A4", &[]any{"Sum", 11})
+	f.SetCellValue("Sheet1", "D2", 6) // cached results
+	f.SetCellValue("Sheet1", "D3", 5)
+	shared, ref := excelize.STCellFormulaTypeShared, "D2:D3"
+	array, arrayRef := excelize.STCellFormulaTypeArray, "B4:B4"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "B2*C2", excelize.FormulaOpts{Type: &shared, Ref: &ref}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B4", "SUM(B2:B3*C2:C3)", excelize.FormulaOpts{Type: &array, Ref: &arrayRef}))
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	off, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, off, "| Pen | 2 | 3 | 6 |")
+	assert.NotContains(t, off, "`")
+
+	inline, err := Convert(tmpFile, ConvertOptions{Formulas: FormulasInline})
+	assert.NoError(t, err)
+	assert.Contains(t, inline, "| Pen | 2 | 3 | 6 `=B2*C2` |\n| Ink | 1 | 5 | 5 `=B3*C3` |\n| Sum | 11 `=SUM(B2:B3*C2:C3)` |  |  |\n")
+
+	only, err := Convert(tmpFile, ConvertOptions{Formulas: FormulasOnly})
+	assert.NoError(t, err)
+	assert.Contains(t, only, "| Ink | 1 | 5 | `=B3*C3` |")
+
+	table, err := Convert(tmpFile, ConvertOptions{Formulas: FormulasTable})
+	assert.NoError(t, err)
+	assert.Contains(t, table, "| Ink | 1 | 5 | 5 |\n| Sum | 11 |  |  |\n\n### Formulas\n\n"+
+		"| Cell | Formula |\n| --- | --- |\n| D2 | `=B2*C2` |\n| D3 | `=B3*C3` |\n| B4 | `=SUM(B2:B3*C2:C3)` |\n")
+
+	// HTML tables escape their cells, so formulas are plain text there
+	assert.NoError(t, f.MergeCell("Sheet1", "C4", "D4"))
+	assert.NoError(t, f.SaveAs(tmpFile))
+	htmlTable, err := Convert(tmpFile, ConvertOptions{Formulas: FormulasInline, MergedCells: mdtable.MergedCellsHTML})
+	assert.NoError(t, err)
+	assert.Contains(t, htmlTable, "<tr><td>Pen</td><td>2</td><td>3</td><td>6 =B2*C2</td></tr>")
+	assert.Contains(t, htmlTable, `<tr><td>Sum</td><td>11 =SUM(B2:B3*C2:C3)</td><td colspan="2"></td></tr>`)
+	assert.NotContains(t, htmlTable, "`")
+}
+
+func TestConvert_Ranges(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetRow("Sheet1", "A1", &[]any{"Region", "Sales"})
+	f.SetSheetRow("Sheet1", "A2", &[]any{"North", 10})
+	f.SetSheetRow("Sheet1", "A3", &[]any{"South", 12})
+	assert.NoError(t, f.AddTable("Sheet1", &excelize.Table{Range: "A1:B3", Name: "SalesTable"}))
+
+	f.NewSheet("My Summary")
+	f.SetSheetRow("My Summary", "A1", &[]any{"Report"})
+	f.SetSheetRow("My Summary", "B3", &[]any{"Metric", "Value", "Note"})
+	f.SetSheetRow("My Summary", "B4", &[]any{"Total", 22, "ok"})
+	assert.NoError(t, f.SetDefinedName(&excelize.DefinedName{Name: "Totals", RefersTo: "'My Summary'!$B$3:$C$4"}))
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	result, err := Convert(tmpFile, ConvertOptions{Ranges: []string{"SalesTable", "Totals", "'My Summary'!C3:D4"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "## SalesTable\n\n| Region | Sales |\n| --- | --- |\n| North | 10 |\n| South | 12 |\n"+
+		"\n## Totals\n\n| Metric | Value |\n| --- | --- |\n| Total | 22 |\n"+
+		"\n## 'My Summary'!C3:D4\n\n| Value | Note |\n| --- | --- |\n| 22 | ok |\n", result)
+
+	// Unqualified references use the first selected sheet
+	result, err = Convert(tmpFile, ConvertOptions{SheetNames: []string{"My Summary"}, Ranges: []string{"B4"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "## My Summary!B4\n\n| Total |\n| --- |\n", result)
+
+	_, err = Convert(tmpFile, ConvertOptions{Ranges: []string{"Missing!A1"}})
+	assert.Error(t, err)
+	_, err = Convert(tmpFile, ConvertOptions{Ranges: []string{"NoSuchName"}})
+	assert.Error(t, err)
+
+	// A merge cut by the range edge keeps its value in the new top-left cell
+	rng := sheetRange{FromRow: 1, FromCol: 1, ToRow: 2, ToCol: 2}
+	sheet := [][]string{{"x", "Group"}, {"a", "", "b"}, {"c", "d", "e"}}
+	rows, merges := rng.crop(sheet, []mdtable.MergedRange{{Row: 0, Col: 1, Rows: 2, Cols: 2}})
+	assert.Equal(t, [][]string{{"Group", "b"}, {"d", "e"}}, rows)
+	assert.Equal(t, []mdtable.MergedRange{{Row: 0, Col: 0, Rows: 1, Cols: 2}}, merges)
+
+	// The sheet's rows are left as they were
+	rows[1][0] = "changed"
+	assert.Equal(t, [][]string{{"x", "Group"}, {"a", "", "b"}, {"c", "d", "e"}}, sheet)
+}
+
+func TestConvert_ExcelTables(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetCellValue("Sheet1", "A1", "Quarterly report")
+	f.SetSheetRow("Sheet1", "B3", &[]any{"Region", "Sales"})
+	f.SetSheetRow("Sheet1", "B4", &[]any{"North", 10})
+	f.SetSheetRow("Sheet1", "B5", &[]any{"South", 12})
+	f.SetSheetRow("Sheet1", "B6", &[]any{"Total", 22})
+	assert.NoError(t, f.AddTable("Sheet1", &excelize.Table{Range: "B3:C6", Name: "Sales"}))
+	// Without a header row excelize places the table one row below the given range (E4:F4)
+	hidden := false
+	assert.NoError(t, f.AddTable("Sheet1", &excelize.Table{Range: "E3:F3", Name: "Codes", ShowHeaderRow: &hidden}))
+	f.SetSheetRow("Sheet1", "E4", &[]any{"x", 1})
+	f.NewSheet("Notes")
+	f.SetCellValue("Notes", "A1", "scratch")
+
+	// excelize cannot declare a totals row, so patch the table part
+	part, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/tables/table1.xml", []byte(strings.Replace(string(part.([]byte)), `ref="B3:C6"`, `ref="B3:C6" totalsRowCount="1"`, 1)))
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	salesMd := "## Sales\n\n| Region | Sales |\n| --- | --- |\n| North | 10 |\n| South | 12 |\n| **Total** | **22** |\n"
+	codesMd := "## Codes\n\n| Column1 | Column2 |\n| --- | --- |\n| x | 1 |\n"
+
+	ignore, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, ignore, "## Sheet1\n\n| Quarterly report |")
+
+	detect, err := Convert(tmpFile, ConvertOptions{Tables: TablesDetect})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n| Quarterly report |\n| --- |\n\n"+salesMd+"\n"+codesMd+"\n## Notes\n\n| scratch |\n| --- |\n", detect)
+
+	only, err := Convert(tmpFile, ConvertOptions{Tables: TablesOnly})
+	assert.NoError(t, err)
+	assert.Equal(t, salesMd+"\n"+codesMd, only)
+
+	// Tables picked by name use their declared header and totals too
+	byName, err := Convert(tmpFile, ConvertOptions{Ranges: []string{"Sales"}})
+	assert.NoError(t, err)
+	assert.Equal(t, salesMd, byName)
+
+	// Totals are not wrapped in Markdown emphasis inside HTML tables
+	assert.NoError(t, f.MergeCell("Sheet1", "B4", "B5"))
+	assert.NoError(t, f.SaveAs(tmpFile))
+	htmlTable, err := Convert(tmpFile, ConvertOptions{Ranges: []string{"Sales"}, MergedCells: mdtable.MergedCellsHTML})
+	assert.NoError(t, err)
+	assert.Contains(t, htmlTable, "<tr><td>Total</td><td>22</td></tr>")
+	assert.NotContains(t, htmlTable, "**")
+	blank, err := Convert(tmpFile, ConvertOptions{Ranges: []string{"Sales"}})
+	assert.NoError(t, err)
+	assert.Contains(t, blank, "| **Total** | **22** |")
+
+	assert.Equal(t, " **Total** ", strongCell(" Total "))
+}
+
+func TestConvert_Layout(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetCellValue("Sheet1", "B2", "Sales report")
+	f.SetCellValue("Sheet1", "B3", "FY2026")
+	f.SetSheetRow("Sheet1", "B5", &[]any{"Region", "Q1", "Q2"})
+	f.SetSheetRow("Sheet1", "B6", &[]any{"North", 10, 11})
+	f.SetSheetRow("Sheet1", "B7", &[]any{"South", 12, 13})
+	f.SetSheetRow("Sheet1", "B10", &[]any{"Owner", "Team"})
+	f.SetSheetRow("Sheet1", "B11", &[]any{"Ann", "Ops"})
+	f.NewSheet("Banner")
+	f.SetCellValue("Banner", "A1", "Inventory")
+	f.SetSheetRow("Banner", "A2", &[]any{"Item", "Count"})
+	f.SetSheetRow("Banner", "A3", &[]any{"Bolt", 5})
+	f.NewSheet("Text")
+	f.SetSheetRow("Text", "A1", &[]any{"Name", "Role"})
+	f.SetSheetRow("Text", "A2", &[]any{"Ann", "Dev"})
+	f.SetSheetRow("Text", "A3", &[]any{"Bob", "QA", "On leave"})
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	// Default keeps the first row as header, blank edges included
+	plain, err := Convert(tmpFile, ConvertOptions{SheetNames: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, "## Sheet1\n\n|  |  |  |  |\n"))
+
+	trimmed, err := Convert(tmpFile, ConvertOptions{SheetNames: []string{"Sheet1"}, Layout: LayoutOptions{Trim: true}})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(trimmed, "## Sheet1\n\n| Sales report |  |  |\n| --- | --- | --- |\n| FY2026 |  |  |\n|  |  |  |\n| Region | Q1 | Q2 |\n"))
+	assert.True(t, strings.HasSuffix(trimmed, "| Ann | Ops |  |\n"))
+
+	split, err := Convert(tmpFile, ConvertOptions{
+		SheetNames: []string{"Sheet1"},
+		Layout:     LayoutOptions{Trim: true, SplitBlocks: true, DetectHeader: true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n"+
+		"Sales report\n\nFY2026\n\n"+
+		"| Region | Q1 | Q2 |\n| --- | --- | --- |\n| North | 10 | 11 |\n| South | 12 | 13 |\n"+
+		"\n| Owner | Team |\n| --- | --- |\n| Ann | Ops |\n", split)
+
+	// A banner row inside the block is detected and written above the table
+	banner, err := Convert(tmpFile, ConvertOptions{SheetNames: []string{"Banner"}, Layout: LayoutOptions{DetectHeader: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Banner\n\nInventory\n\n| Item | Count |\n| --- | --- |\n| Bolt | 5 |\n", banner)
+
+	// In a text-only table a fuller data row does not replace the header
+	text, err := Convert(tmpFile, ConvertOptions{SheetNames: []string{"Text"}, Layout: LayoutOptions{DetectHeader: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Text\n\n| Name | Role |  |\n| --- | --- | --- |\n| Ann | Dev |  |\n| Bob | QA | On leave |\n", text)
+
+	// An explicit header row overrides detection
+	override, err := Convert(tmpFile, ConvertOptions{SheetNames: []string{"Banner"}, Layout: LayoutOptions{DetectHeader: true, HeaderRow: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Banner\n\n| Inventory |  |\n| --- | --- |\n| Item | Count |\n| Bolt | 5 |\n", override)
+}
+
+func TestConvert_Hidden(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetRow("Sheet1", "A1", &[]any{"Name", "Code", "Score"})
+	f.SetSheetRow("Sheet1", "A2", &[]any{"Ann", "x1", 90})
+	f.SetSheetRow("Sheet1", "A3", &[]any{"Bob", "x2", 40})
+	f.SetSheetRow("Sheet1", "A4", &[]any{"Cid", "x3", 75})
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	// Filtering Score > 50 hides row 3, as Excel stores it
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C4", []excelize.AutoFilterOptions{{Column: "C", Expression: "x > 50"}}))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	f.NewSheet("Lookup")
+	f.SetCellValue("Lookup", "A1", "secret")
+	assert.NoError(t, f.SetSheetVisible("Lookup", false))
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	all, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, all, "| Bob | x2 | 40 |")
+	assert.Contains(t, all, "## Lookup\n\n| secret |")
+
+	excluded, err := Convert(tmpFile, ConvertOptions{HiddenSheets: HiddenExclude, HiddenRows: HiddenExclude, HiddenColumns: HiddenExclude})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n| Name | Score |\n| --- | --- |\n| Ann | 90 |\n| Cid | 75 |\n", excluded)
+
+	marked, err := Convert(tmpFile, ConvertOptions{HiddenSheets: HiddenMark, HiddenRows: HiddenMark, HiddenColumns: HiddenMark})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n| Name | Code *(hidden)* | Score |\n| --- | --- | --- |\n| Ann | x1 | 90 |\n| Bob *(hidden)* | x2 | 40 |\n| Cid | x3 | 75 |\n"+
+		"\n## Lookup\n\n*(Hidden sheet)*\n\n| secret |\n| --- |\n", marked)
+
+	// Rows only: hidden columns stay
+	rowsOnly, err := Convert(tmpFile, ConvertOptions{SheetNames: []string{"Sheet1"}, HiddenRows: HiddenExclude})
+	assert.NoError(t, err)
+	assert.NotContains(t, rowsOnly, "Bob")
+	assert.Contains(t, rowsOnly, "| Name | Code | Score |")
+
+	// HTML tables escape their cells, so the marker is plain text there
+	f.SetCellValue("Sheet1", "A5", "All")
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "C5"))
+	assert.NoError(t, f.SaveAs(tmpFile))
+	htmlTable, err := Convert(tmpFile, ConvertOptions{
+		SheetNames:    []string{"Sheet1"},
+		HiddenRows:    HiddenMark,
+		HiddenColumns: HiddenMark,
+		MergedCells:   mdtable.MergedCellsHTML,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, htmlTable, "<tr><th>Name</th><th>Code (hidden)</th><th>Score</th></tr>")
+	assert.Contains(t, htmlTable, "<tr><td>Bob (hidden)</td><td>x2</td><td>40</td></tr>")
+	assert.NotContains(t, htmlTable, "*")
+}
+
+func TestDropHidden(t *testing.T) {
+	rows := [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h", "i"}}
+	// A merge over A1:B2 whose top-left row and column are hidden
+	merges := []mdtable.MergedRange{{Row: 0, Col: 0, Rows: 2, Cols: 2}, {Row: 0, Col: 2, Rows: 1, Cols: 1}}
+	out, kept := dropHidden(rows, merges, []bool{true, false, false}, []bool{true, false, false})
+	assert.Equal(t, [][]string{{"a", "f"}, {"h", "i"}}, out)
+	assert.Equal(t, []mdtable.MergedRange{{Row: 0, Col: 0, Rows: 1, Cols: 1}}, kept)
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h", "i"}}, rows, "input rows should be unchanged")
+
+	// Moving a value into a short row must not grow the caller's row
+	short := [][]string{{"a", "b"}, {}}
+	out, _ = dropHidden(short, []mdtable.MergedRange{{Row: 0, Col: 0, Rows: 2, Cols: 1}}, []bool{true}, nil)
+	assert.Equal(t, [][]string{{"a"}}, out)
+	assert.Equal(t, [][]string{{"a", "b"}, {}}, short)
+}
+
+func TestConvert_Escape(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetRow("Sheet1", "A1", &[]any{"項目", "備註 📝"})
+	f.SetSheetRow("Sheet1", "A2", &[]any{"# 標題", "第一行\n第二行"})
+	f.SetSheetRow("Sheet1", "A3", &[]any{"**粗體** 🚀", "a|b <i>x</i>"})
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	md, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n| 項目 | 備註 📝 |\n| --- | --- |\n"+
+		"| \\# 標題 | 第一行<br>第二行 |\n"+
+		"| \\*\\*粗體\\*\\* 🚀 | a\\|b \\<i>x\\</i> |\n", md)
+
+	preserved, err := Convert(tmpFile, ConvertOptions{Escape: mdtable.EscapePreserve})
+	assert.NoError(t, err)
+	assert.Contains(t, preserved, "| # 標題 | 第一行<br>第二行 |\n| **粗體** 🚀 | a\\|b <i>x</i> |\n")
+
+	// HTML tables escape as HTML, never with backslashes
+	f.MergeCell("Sheet1", "A4", "B4")
+	assert.NoError(t, f.SaveAs(tmpFile))
+	html, err := Convert(tmpFile, ConvertOptions{MergedCells: mdtable.MergedCellsHTML})
+	assert.NoError(t, err)
+	assert.Contains(t, html, "<td>**粗體** 🚀</td><td>a|b &lt;i&gt;x&lt;/i&gt;</td>")
+}
+
+func TestConvert_AlignColumns(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetRow("Sheet1", "A1", &[]any{"Name", "Qty", "Active", "Code", "Mixed", "Empty"})
+	f.SetSheetRow("Sheet1", "A2", &[]any{"Ann", 3, true, 101, "x"})
+	f.SetSheetRow("Sheet1", "A3", &[]any{"Bob", 4.5, false, 102, 1})
+	f.SetSheetRow("Sheet1", "A4", &[]any{"Cid", 6, true, 103, "y"})
+	centered, err := f.NewStyle(&excelize.Style{Alignment: &excelize.Alignment{Horizontal: "center"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D2", "D4", centered))
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	plain, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, plain, "\n| --- | --- | --- | --- | --- | --- |\n")
+
+	aligned, err := Convert(tmpFile, ConvertOptions{AlignColumns: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n| Name | Qty | Active | Code | Mixed | Empty |\n"+
+		"| :--- | ---: | :---: | :---: | :--- | --- |\n"+
+		"| Ann | 3 | TRUE | 101 | x |  |\n"+
+		"| Bob | 4.5 | FALSE | 102 | 1 |  |\n"+
+		"| Cid | 6 | TRUE | 103 | y |  |\n", aligned)
+
+	// A range aligns from its own rows below its first row
+	rng, err := Convert(tmpFile, ConvertOptions{AlignColumns: true, Ranges: []string{"B2:B4"}})
+	assert.NoError(t, err)
+	assert.Contains(t, rng, "| 3 |\n| ---: |\n| 4.5 |\n")
+}
+
+func TestConvert_TableStyle(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetRow("Sheet1", "A1", &[]any{"名稱", "Qty", "Note"})
+	f.SetSheetRow("Sheet1", "A2", &[]any{"蘋果", 12, "fresh 🍎"})
+	f.SetSheetRow("Sheet1", "A3", &[]any{"Pear", 3, "café"})
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	padded, err := Convert(tmpFile, ConvertOptions{TableStyle: mdtable.TablePadded})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n"+
+		"| 名稱 | Qty | Note     |\n"+
+		"| ---- | --- | -------- |\n"+
+		"| 蘋果 | 12  | fresh 🍎 |\n"+
+		"| Pear | 3   | café     |\n", padded)
+
+	aligned, err := Convert(tmpFile, ConvertOptions{TableStyle: mdtable.TablePadded, AlignColumns: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n"+
+		"| 名稱 |  Qty | Note     |\n"+
+		"| :--- | ---: | :------- |\n"+
+		"| 蘋果 |   12 | fresh 🍎 |\n"+
+		"| Pear |    3 | café     |\n", aligned)
+
+	compact, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, compact, "| 蘋果 | 12 | fresh 🍎 |\n")
+}
+
+func TestConvert_RichText(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetRow("Sheet1", "A1", &[]any{"Item", "Link"})
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A2", []excelize.RichTextRun{
+		{Text: "plain "},
+		{Text: "bold ", Font: &excelize.Font{Bold: true}},
+		{Text: "a*b", Font: &excelize.Font{Italic: true}},
+		{Text: " and ", Font: &excelize.Font{Color: "FF0000"}},
+		{Text: "gone", Font: &excelize.Font{Strike: true}},
+	}))
+	f.SetCellValue("Sheet1", "B2", "Site (docs)")
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2", "https://example.com/a b", "External"))
+	f.SetCellValue("Sheet1", "B3", "See data")
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3", "'Data Sheet 2'!A1", "Location"))
+	f.SetCellValue("Sheet1", "B4", "Here")
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B4", "B2", "Location"))
+	f.SetCellValue("Sheet1", "B5", "App")
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B5", "https://example.com/#!/page", "External"))
+	f.SetCellValue("Sheet1", "B6", "Mail")
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B6", "mailto:a!b@example.com", "External"))
+	f.NewSheet("Data Sheet 2")
+	f.SetCellValue("Data Sheet 2", "A1", "value")
+
+	tmpFile := filepath.Join(t.TempDir(), "test.xlsx")
+	assert.NoError(t, f.SaveAs(tmpFile))
+
+	plain, err := Convert(tmpFile, ConvertOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, plain, "| plain bold a\\*b and gone | Site (docs) |\n|  | See data |\n")
+
+	rich, err := Convert(tmpFile, ConvertOptions{RichText: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "## Sheet1\n\n| Item | Link |\n| --- | --- |\n"+
+		"| plain **bold** *a\\*b* and ~~gone~~ | [Site (docs)](<https://example.com/a b>) |\n"+
+		"|  | [See data](#data-sheet-2) |\n"+
+		"|  | Here |\n"+
+		"|  | [App](https://example.com/#!/page) |\n"+
+		"|  | [Mail](mailto:a!b@example.com) |\n"+
+		"\n## Data Sheet 2\n\n| value |\n| --- |\n", rich)
+
+	// Without the target sheet's section the link text stays plain
+	oneSheet, err := Convert(tmpFile, ConvertOptions{RichText: true, SheetNames: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	assert.Contains(t, oneSheet, "|  | See data |\n")
+
+	seen := make(map[string]int)
+	assert.Equal(t, "#季度-報告_2026", headingAnchor("季度 報告_2026!", seen))
+	assert.Equal(t, "#sales", headingAnchor("Sales", seen))
+	assert.Equal(t, "#sales-1", headingAnchor("Sales", seen))
+	assert.Equal(t, "#sales-1-1", headingAnchor("Sales-1", seen))
+	assert.Equal(t, "#sales-2", headingAnchor("Sales", seen))
+
+	// Anchors count every heading written, the formulas headings included
+	g := excelize.NewFile()
+	defer g.Close()
+	g.SetSheetRow("Sheet1", "A1", &[]any{"Total", "Details"})
+	assert.NoError(t, g.SetCellFormula("Sheet1", "A2", "=1+1"))
+	g.SetCellValue("Sheet1", "B2", "Back")
+	assert.NoError(t, g.SetCellHyperLink("Sheet1", "B2", "Sheet1!A1", "Location"))
+	g.SetCellValue("Sheet1", "B3", "Next")
+	assert.NoError(t, g.SetCellHyperLink("Sheet1", "B3", "Formulas!A1", "Location"))
+	g.NewSheet("Formulas")
+	g.SetCellValue("Formulas", "A1", "notes")
+	assert.NoError(t, g.SaveAs(tmpFile))
+
+	numbered, err := Convert(tmpFile, ConvertOptions{RichText: true, Formulas: FormulasTable})
+	assert.NoError(t, err)
+	assert.Contains(t, numbered, "| [Back](#sheet1) |")
+	assert.Contains(t, numbered, "| [Next](#formulas-1) |")
+	assert.Contains(t, numbered, "### Formulas\n")
+	assert.Contains(t, numbered, "## Formulas\n")
+
+	// Links are resolved before cells are padded
+	padded, err := Convert(tmpFile, ConvertOptions{RichText: true, TableStyle: mdtable.TablePadded})
+	assert.NoError(t, err)
+	assert.Contains(t, padded, "|       | [Back](#sheet1)   |\n|       | [Next](#formulas) |\n")
+
+	for target, want := range map[string]bool{
+		"https://example.com/#!/page": true,
+		"mailto:a!b@x":                true,
+		"tel:1234":                    true,
+		"Sheet1!A1":                   false,
+		"'Data Sheet 2'!A1":           false,
+		"A1:B2":                       false,
+		"AB:AC":                       false,
+		`C:\docs\report.xlsx`:         false,
+		"report.xlsx":                 false,
+	} {
+		assert.Equal(t, want, isURL(target), target)
+	}
+}
+
+func TestIsDateFormat(t *testing.T) {
+	for code, want := range map[string]bool{
+		"yyyy-mm-dd":        true,
+		"[$-409]mmm d, yy":  true,
+		"[h]:mm":            true,
+		"hh:mm AM/PM":       true,
+		"General":           false,
+		"#,##0.00":          false,
+		"0.00E+00":          false,
+		`0 "days"`:          false,
+		`[Red]#,##0;\-0`:    false,
+		"_(* #,##0_);_(@_)": false,
+	} {
+		assert.Equal(t, want, isDateFormat(code), code)
+	}
+}
+
 func TestConvertWithTestdata(t *testing.T) {
 	samplePath := filepath.Join("..", "..", "testdata", "sample.xlsx")
 	if _, err := os.Stat(samplePath); os.IsNotExist(err) {
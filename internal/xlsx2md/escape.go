@@ -0,0 +1,14 @@
+package xlsx2md
+
+import (
+	"ar-tools/internal/mdtable"
+)
+
+// escapeRow returns a copy of row with each cell escaped.
+func escapeRow(row []string, mode mdtable.EscapeMode) []string {
+	out := make([]string, len(row))
+	for j, cell := range row {
+		out[j] = mdtable.EscapeCell(cell, mode)
+	}
+	return out
+}
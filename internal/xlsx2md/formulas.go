@@ -0,0 +1,93 @@
+package xlsx2md
+
+import (
+	"strings"
+
+	"ar-tools/internal/mdtable"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// FormulaMode selects whether cell formulas are written.
+type FormulaMode int
+
+const (
+	// FormulasOff writes the cached formula results only.
+	FormulasOff FormulaMode = iota
+	// FormulasInline writes each result followed by its formula in a code
+	// span, or as plain text in HTML tables.
+	FormulasInline
+	// FormulasOnly writes the formula in a code span, or as plain text in
+	// HTML tables, instead of the result.
+	FormulasOnly
+	// FormulasTable keeps results in the sheet table and lists every formula
+	// in a separate "Formulas" table below it.
+	FormulasTable
+)
+
+// cellFormula is a formula cell at a zero-based row and column of the converted grid.
+type cellFormula struct {
+	row, col int
+	cell     string // sheet cell name, e.g. "B10"
+	formula  string // with the leading "="
+}
+
+// sheetFormulas reads the formulas of the cells in the rows grid, whose
+// top-left cell sits at zero-based (rowOff, colOff) on the sheet, in
+// row-major order. Shared formulas are expanded for each cell; array
+// formulas appear on the top-left cell of their range.
+func sheetFormulas(f *excelize.File, sheet string, rows [][]string, rowOff, colOff int) ([]cellFormula, error) {
+	maxCols := 0
+	for _, row := range rows {
+		maxCols = max(maxCols, len(row))
+	}
+	var formulas []cellFormula
+	for i := range rows {
+		for j := 0; j < maxCols; j++ {
+			cell, err := excelize.CoordinatesToCellName(colOff+j+1, rowOff+i+1)
+			if err != nil {
+				return nil, err
+			}
+			formula, err := f.GetCellFormula(sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			if formula != "" {
+				formulas = append(formulas, cellFormula{row: i, col: j, cell: cell, formula: "=" + strings.TrimPrefix(formula, "=")})
+			}
+		}
+	}
+	return formulas, nil
+}
+
+// withFormulas returns a copy of rows with formulas written into their cells,
+// as code spans or, for HTML tables, as plain text.
+func withFormulas(rows [][]string, formulas []cellFormula, mode FormulaMode, html bool) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		out[i] = append([]string(nil), row...)
+	}
+	for _, fc := range formulas {
+		for len(out[fc.row]) <= fc.col {
+			out[fc.row] = append(out[fc.row], "")
+		}
+		code := fc.formula
+		if !html {
+			code = mdtable.CodeSpan(code)
+		}
+		if value := out[fc.row][fc.col]; mode == FormulasInline && value != "" {
+			code = value + " " + code
+		}
+		out[fc.row][fc.col] = code
+	}
+	return out
+}
+
+// formulasToMarkdown lists formula cells as a "Cell | Formula" table.
+func formulasToMarkdown(formulas []cellFormula, style mdtable.TableStyle) string {
+	rows := [][]string{{"Cell", "Formula"}}
+	for _, fc := range formulas {
+		rows = append(rows, []string{fc.cell, mdtable.CodeSpan(fc.formula)})
+	}
+	return mdtable.RenderTable(rows, nil, mdtable.TableOptions{Style: style})
+}
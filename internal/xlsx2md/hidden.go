@@ -0,0 +1,157 @@
+package xlsx2md
+
+import (
+	"slices"
+
+	"ar-tools/internal/mdtable"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// HiddenMode selects how hidden sheets, rows or columns are converted.
+type HiddenMode int
+
+const (
+	// HiddenInclude converts hidden content like visible content.
+	HiddenInclude HiddenMode = iota
+	// HiddenExclude leaves hidden content out, matching what Excel shows.
+	HiddenExclude
+	// HiddenMark converts hidden content with a "*(hidden)*" marker, or a
+	// plain "(hidden)" in HTML tables.
+	HiddenMark
+)
+
+const (
+	hiddenMarker     = "*(hidden)*"
+	hiddenMarkerHTML = "(hidden)" // HTML table cells are escaped, not read as Markdown
+)
+
+// sheetHidden reports which rows and columns of the rows grid, whose top-left
+// cell sits at zero-based (rowOff, colOff) on the sheet, are hidden. Rows
+// filtered out by an AutoFilter are stored as hidden rows, so they count too.
+func sheetHidden(f *excelize.File, sheet string, rows [][]string, rowOff, colOff int) (hiddenRows, hiddenCols []bool, err error) {
+	hiddenRows = make([]bool, len(rows))
+	maxCols := 0
+	for i, row := range rows {
+		maxCols = max(maxCols, len(row))
+		visible, err := f.GetRowVisible(sheet, rowOff+i+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		hiddenRows[i] = !visible
+	}
+	hiddenCols = make([]bool, maxCols)
+	for j := range hiddenCols {
+		name, err := excelize.ColumnNumberToName(colOff + j + 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		visible, err := f.GetColVisible(sheet, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		hiddenCols[j] = !visible
+	}
+	return hiddenRows, hiddenCols, nil
+}
+
+// dropHidden returns a copy of rows and merged ranges without hidden rows
+// and columns. A merge whose top-left cell is hidden moves its value to its
+// first visible cell; a merge with no visible cells is dropped.
+func dropHidden(rows [][]string, merges []mdtable.MergedRange, hiddenRows, hiddenCols []bool) ([][]string, []mdtable.MergedRange) {
+	rows = slices.Clone(rows)
+	for i, row := range rows {
+		rows[i] = slices.Clone(row)
+	}
+	isHidden := func(flags []bool, i int) bool { return i < len(flags) && flags[i] }
+	// newIndex[i] is the index of i once hidden entries before it are gone
+	newIndex := func(flags []bool, i int) int {
+		n := i
+		for k := 0; k < i && k < len(flags); k++ {
+			if flags[k] {
+				n--
+			}
+		}
+		return n
+	}
+
+	var kept []mdtable.MergedRange
+	for _, m := range merges {
+		top, left := -1, -1
+		rowCount, colCount := 0, 0
+		for r := m.Row; r < m.Row+m.Rows; r++ {
+			if !isHidden(hiddenRows, r) {
+				if top < 0 {
+					top = r
+				}
+				rowCount++
+			}
+		}
+		for c := m.Col; c < m.Col+m.Cols; c++ {
+			if !isHidden(hiddenCols, c) {
+				if left < 0 {
+					left = c
+				}
+				colCount++
+			}
+		}
+		if rowCount == 0 || colCount == 0 {
+			continue
+		}
+		if (top != m.Row || left != m.Col) && m.Row < len(rows) && m.Col < len(rows[m.Row]) && top < len(rows) {
+			for len(rows[top]) <= left {
+				rows[top] = append(rows[top], "")
+			}
+			rows[top][left] = rows[m.Row][m.Col]
+		}
+		kept = append(kept, mdtable.MergedRange{
+			Row: newIndex(hiddenRows, top), Col: newIndex(hiddenCols, left),
+			Rows: rowCount, Cols: colCount,
+		})
+	}
+
+	var out [][]string
+	for i, row := range rows {
+		if isHidden(hiddenRows, i) {
+			continue
+		}
+		var cells []string
+		for j, cell := range row {
+			if !isHidden(hiddenCols, j) {
+				cells = append(cells, cell)
+			}
+		}
+		out = append(out, cells)
+	}
+	return out, kept
+}
+
+// markHidden returns a copy of rows with hidden rows marked in their first
+// cell and hidden columns marked in their first row.
+func markHidden(rows [][]string, hiddenRows, hiddenCols []bool, marker string) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		out[i] = append([]string(nil), row...)
+	}
+	mark := func(i, j int) {
+		for len(out[i]) <= j {
+			out[i] = append(out[i], "")
+		}
+		if out[i][j] == "" {
+			out[i][j] = marker
+		} else {
+			out[i][j] += " " + marker
+		}
+	}
+	for i, hidden := range hiddenRows {
+		if hidden && i < len(out) {
+			mark(i, 0)
+		}
+	}
+	for j, hidden := range hiddenCols {
+		if hidden && len(out) > 0 {
+			mark(0, j)
+		}
+	}
+	return out
+}
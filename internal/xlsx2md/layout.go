@@ -0,0 +1,165 @@
+package xlsx2md
+
+import (
+	"strconv"
+	"strings"
+
+	"ar-tools/internal/mdtable"
+)
+
+// LayoutOptions clean up sheets that are not a single table starting at A1.
+// The zero value converts the cells as they are, with the first row as header.
+type LayoutOptions struct {
+	// Trim drops fully empty leading and trailing rows and columns.
+	Trim bool
+	// SplitBlocks splits the sheet at fully empty rows into separate tables.
+	SplitBlocks bool
+	// DetectHeader looks for the header among the leading rows up to the
+	// first one with more than one cell, and uses the first with the most
+	// non-empty text cells. Rows above it, such as a title banner, are
+	// written as text, as is a block of single cells followed by another block.
+	DetectHeader bool
+	// HeaderRow sets the 1-based header row within each table, overriding
+	// DetectHeader. 0 keeps the first (or detected) row.
+	HeaderRow int
+}
+
+// tableBlock is one table of a section with the text rows written above it.
+type tableBlock struct {
+	Preamble []string
+	Rows     [][]string
+	Merges   []mdtable.MergedRange
+	Area     sheetRange // where Rows sit in the grid the block was cut from
+}
+
+// blocks splits and trims rows into tables according to the layout options.
+func (l LayoutOptions) blocks(rows [][]string, merges []mdtable.MergedRange) []tableBlock {
+	// Row groups: the whole grid, the span between the first and last
+	// non-empty rows, or each run of non-empty rows
+	var groups [][2]int
+	switch {
+	case l.SplitBlocks:
+		start := -1
+		for i, row := range rows {
+			switch {
+			case !isBlankRow(row) && start < 0:
+				start = i
+			case isBlankRow(row) && start >= 0:
+				groups = append(groups, [2]int{start, i - 1})
+				start = -1
+			}
+		}
+		if start >= 0 {
+			groups = append(groups, [2]int{start, len(rows) - 1})
+		}
+	case l.Trim:
+		first, last := -1, -1
+		for i, row := range rows {
+			if !isBlankRow(row) {
+				if first < 0 {
+					first = i
+				}
+				last = i
+			}
+		}
+		if first >= 0 {
+			groups = append(groups, [2]int{first, last})
+		}
+	default:
+		groups = append(groups, [2]int{0, len(rows) - 1})
+	}
+
+	var blocks []tableBlock
+	for n, g := range groups {
+		rng := sheetRange{FromRow: g[0], ToRow: g[1], ToCol: -1}
+		if l.Trim {
+			rng.FromCol, rng.ToCol = usedColumns(rows[g[0] : g[1]+1])
+		}
+		blockRows, blockMerges := rng.crop(rows, merges)
+
+		header := 0
+		switch {
+		case l.HeaderRow > 0 && l.HeaderRow <= len(blockRows):
+			header = l.HeaderRow - 1
+		case l.DetectHeader && n < len(groups)-1 && isTextBlock(blockRows):
+			// A banner block above another table is written as text
+			header = len(blockRows)
+		case l.DetectHeader:
+			header = detectHeader(blockRows)
+		}
+		var preamble []string
+		for _, row := range blockRows[:header] {
+			if line := strings.Join(nonEmpty(row), " "); line != "" {
+				preamble = append(preamble, line)
+			}
+		}
+		if header > 0 {
+			blockRows, blockMerges = sheetRange{FromRow: header, ToRow: -1, ToCol: -1}.crop(blockRows, blockMerges)
+			rng.FromRow += header
+		}
+		blocks = append(blocks, tableBlock{Preamble: preamble, Rows: blockRows, Merges: blockMerges, Area: rng})
+	}
+	return blocks
+}
+
+// usedColumns returns the first and last columns holding a non-empty cell.
+func usedColumns(rows [][]string) (int, int) {
+	first, last := -1, -1
+	for _, row := range rows {
+		for j, cell := range row {
+			if cell == "" {
+				continue
+			}
+			if first < 0 || j < first {
+				first = j
+			}
+			last = max(last, j)
+		}
+	}
+	if first < 0 {
+		return 0, -1
+	}
+	return first, last
+}
+
+// detectHeader returns the first row with the most non-empty text
+// (non-numeric) cells, searching no further than the first row with more
+// than one cell: data rows, even text-only ones, sit below the header.
+func detectHeader(rows [][]string) int {
+	best, bestCount := 0, 0
+	for i, row := range rows {
+		count := 0
+		for _, cell := range row {
+			if _, err := strconv.ParseFloat(cell, 64); cell != "" && err != nil {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = i, count
+		}
+		if len(nonEmpty(row)) > 1 {
+			break
+		}
+	}
+	return best
+}
+
+// isTextBlock reports whether no row holds more than one non-empty cell.
+func isTextBlock(rows [][]string) bool {
+	for _, row := range rows {
+		if len(nonEmpty(row)) > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func nonEmpty(cells []string) []string {
+	var out []string
+	for _, cell := range cells {
+		if cell = strings.TrimSpace(cell); cell != "" {
+			out = append(out, cell)
+		}
+	}
+	return out
+}
@@ -0,0 +1,169 @@
+package xlsx2md
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"ar-tools/internal/mdtable"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// sheetRange is a block of cells converted as one section. Bounds are
+// zero-based and inclusive; ToRow/ToCol of -1 extend to the end of the sheet.
+type sheetRange struct {
+	Title            string
+	Sheet            string
+	FromRow, FromCol int
+	ToRow, ToCol     int
+	Table            *excelTable  // set when the range is an Excel table
+	Exclude          []sheetRange // blocks left out, such as tables rendered on their own
+	Hidden           bool         // marked as on a hidden sheet
+}
+
+func wholeSheet(sheet string) sheetRange {
+	return sheetRange{Title: sheet, Sheet: sheet, ToRow: -1, ToCol: -1}
+}
+
+// resolveRange looks a reference up as a defined name, an Excel table name,
+// then an A1 reference such as "Summary!B3:H40". Unqualified A1 references
+// refer to defaultSheet.
+func resolveRange(f *excelize.File, ref, defaultSheet string, tables map[string]*excelTable) (sheetRange, error) {
+	for _, dn := range f.GetDefinedName() {
+		if strings.EqualFold(dn.Name, ref) {
+			rng, err := parseRangeRef(f, strings.TrimPrefix(dn.RefersTo, "="), "")
+			if err != nil {
+				return sheetRange{}, fmt.Errorf("defined name %q: %w", ref, err)
+			}
+			rng.Title = dn.Name
+			return rng, nil
+		}
+	}
+
+	for _, sheet := range f.GetSheetList() {
+		ranges, err := sheetTables(f, sheet, tables)
+		if err != nil {
+			return sheetRange{}, err
+		}
+		for _, rng := range ranges {
+			if strings.EqualFold(rng.Title, ref) {
+				return rng, nil
+			}
+		}
+	}
+
+	rng, err := parseRangeRef(f, ref, defaultSheet)
+	if err != nil {
+		return sheetRange{}, fmt.Errorf("range %q: %w", ref, err)
+	}
+	rng.Title = ref
+	if !strings.Contains(ref, "!") {
+		rng.Title = rng.Sheet + "!" + ref
+	}
+	return rng, nil
+}
+
+// parseRangeRef parses "Sheet!A1:B2", "'My Sheet'!$A$1" or "A1:B2" (on defaultSheet).
+func parseRangeRef(f *excelize.File, ref, defaultSheet string) (sheetRange, error) {
+	sheet, cells := defaultSheet, ref
+	if i := strings.LastIndex(ref, "!"); i >= 0 {
+		sheet, cells = ref[:i], ref[i+1:]
+		if len(sheet) >= 2 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+			sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+		}
+	}
+	if idx, err := f.GetSheetIndex(sheet); err != nil || idx < 0 {
+		return sheetRange{}, fmt.Errorf("sheet %q not found", sheet)
+	}
+	if strings.Contains(cells, ",") {
+		return sheetRange{}, fmt.Errorf("multi-area references are not supported")
+	}
+
+	from, to, _ := strings.Cut(strings.ReplaceAll(cells, "$", ""), ":")
+	if to == "" {
+		to = from
+	}
+	fromCol, fromRow, err := excelize.CellNameToCoordinates(from)
+	if err != nil {
+		return sheetRange{}, err
+	}
+	toCol, toRow, err := excelize.CellNameToCoordinates(to)
+	if err != nil {
+		return sheetRange{}, err
+	}
+	return sheetRange{
+		Sheet:   sheet,
+		FromRow: min(fromRow, toRow) - 1,
+		FromCol: min(fromCol, toCol) - 1,
+		ToRow:   max(fromRow, toRow) - 1,
+		ToCol:   max(fromCol, toCol) - 1,
+	}, nil
+}
+
+// contains reports whether a zero-based sheet cell lies inside the range.
+func (r sheetRange) contains(row, col int) bool {
+	return row >= r.FromRow && col >= r.FromCol &&
+		(r.ToRow < 0 || row <= r.ToRow) && (r.ToCol < 0 || col <= r.ToCol)
+}
+
+// excludes reports whether a zero-based sheet cell lies in one of the excluded blocks.
+func (r sheetRange) excludes(row, col int) bool {
+	for _, ex := range r.Exclude {
+		if ex.contains(row, col) {
+			return true
+		}
+	}
+	return false
+}
+
+// crop cuts the range out of a sheet's rows and merged ranges, shifting
+// merges to the new origin. A merge cut by the range edge moves its value
+// into its new top-left cell. The cropped rows are copies, so rows is never
+// modified.
+func (r sheetRange) crop(rows [][]string, merges []mdtable.MergedRange) ([][]string, []mdtable.MergedRange) {
+	if r.FromRow == 0 && r.FromCol == 0 && r.ToRow < 0 && r.ToCol < 0 {
+		return rows, merges
+	}
+
+	var out [][]string
+	for i := r.FromRow; i < len(rows) && (r.ToRow < 0 || i <= r.ToRow); i++ {
+		row := rows[i]
+		end := len(row)
+		if r.ToCol >= 0 {
+			end = min(end, r.ToCol+1)
+		}
+		if r.FromCol >= end {
+			out = append(out, nil)
+			continue
+		}
+		out = append(out, slices.Clone(row[r.FromCol:end]))
+	}
+
+	var cropped []mdtable.MergedRange
+	for _, m := range merges {
+		top, left := max(m.Row, r.FromRow), max(m.Col, r.FromCol)
+		bottom, right := m.Row+m.Rows-1, m.Col+m.Cols-1
+		if r.ToRow >= 0 {
+			bottom = min(bottom, r.ToRow)
+		}
+		if r.ToCol >= 0 {
+			right = min(right, r.ToCol)
+		}
+		if top > bottom || left > right {
+			continue
+		}
+		cm := mdtable.MergedRange{
+			Row: top - r.FromRow, Col: left - r.FromCol,
+			Rows: bottom - top + 1, Cols: right - left + 1,
+		}
+		if (top != m.Row || left != m.Col) && m.Row < len(rows) && m.Col < len(rows[m.Row]) && cm.Row < len(out) {
+			for len(out[cm.Row]) <= cm.Col {
+				out[cm.Row] = append(out[cm.Row], "")
+			}
+			out[cm.Row][cm.Col] = rows[m.Row][m.Col]
+		}
+		cropped = append(cropped, cm)
+	}
+	return out, cropped
+}
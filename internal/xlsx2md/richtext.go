@@ -0,0 +1,255 @@
+package xlsx2md
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"ar-tools/internal/mdtable"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// richCells renders the cells of a sheet that hold formatted rich text runs
+// or a hyperlink as Markdown, keyed by zero-based [row, col]. Text is escaped
+// with mode.
+func richCells(f *excelize.File, sheet string, rows [][]string, mode mdtable.EscapeMode) (map[[2]int]richCell, error) {
+	cells := make(map[[2]int]richCell)
+	for i, row := range rows {
+		for j, value := range row {
+			if value == "" {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
+			if err != nil {
+				return nil, err
+			}
+			runs, err := f.GetCellRichText(sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			hasLink, target, err := f.GetCellHyperLink(sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			var rc richCell
+			if hasLink {
+				rc.url, rc.sheet = linkTarget(f, target)
+			}
+			if rc.url == "" && rc.sheet == "" && !hasFormatting(runs) {
+				continue
+			}
+
+			rc.text = mdtable.EscapeCell(value, mode)
+			if hasFormatting(runs) {
+				rc.text = mdtable.RunsToMarkdown(markdownRuns(runs), mode)
+			}
+			cells[[2]int{i, j}] = rc
+		}
+	}
+	return cells, nil
+}
+
+// richCell is a cell written as Markdown. A link to a sheet is resolved to
+// that sheet's section anchor once every section is known.
+type richCell struct {
+	text  string // formatted runs, or the escaped cell text
+	url   string // link target outside the workbook
+	sheet string // lower-cased name of the sheet linked to
+}
+
+// markdown renders the cell, linked when its target has an anchor.
+func (c richCell) markdown(anchors map[string]string) string {
+	link := c.url
+	if c.sheet != "" {
+		link = anchors[c.sheet]
+	}
+	if link == "" {
+		return c.text
+	}
+	return mdtable.Link(c.text, link)
+}
+
+// richMark delimits the placeholder a rich cell leaves in the grid. Cell text
+// cannot hold it, as XML has no NUL character.
+const richMark = "\x00"
+
+func richPlaceholder(n int) string {
+	return richMark + strconv.Itoa(n) + richMark
+}
+
+// resolve replaces the rich cell placeholders in text with their Markdown.
+func (c *rangeContent) resolve(text string, anchors map[string]string) string {
+	if !strings.Contains(text, richMark) {
+		return text
+	}
+	parts := strings.Split(text, richMark)
+	for i := 1; i < len(parts); i += 2 {
+		if n, err := strconv.Atoi(parts[i]); err == nil && n < len(c.rich) {
+			parts[i] = c.rich[n].markdown(anchors)
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// linkTarget splits a hyperlink into a URL, or the lower-cased name of the
+// sheet holding the cell or defined name it points to. Bare cell references
+// such as "B2" point into the same sheet and return neither; "Sheet1!B2"
+// links to Sheet1's heading from any sheet.
+func linkTarget(f *excelize.File, target string) (url, sheet string) {
+	if isURL(target) {
+		return target, ""
+	}
+	location := strings.TrimPrefix(target, "#")
+	for _, dn := range f.GetDefinedName() {
+		if strings.EqualFold(dn.Name, location) {
+			location = strings.TrimPrefix(dn.RefersTo, "=")
+		}
+	}
+	i := strings.LastIndex(location, "!")
+	if i < 0 {
+		if isCellRange(location) {
+			return "", ""
+		}
+		return target, "" // file path
+	}
+	sheet = location[:i]
+	if len(sheet) >= 2 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+		sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	return "", strings.ToLower(sheet)
+}
+
+// isURL reports whether a hyperlink target is a URL, such as
+// "https://example.com/#!/page" or "mailto:a!b@x", rather than a location in
+// the workbook. Sheet names cannot hold ":", so a scheme is never a sheet.
+func isURL(target string) bool {
+	if strings.Contains(target, "://") {
+		return true
+	}
+	scheme, _, ok := strings.Cut(target, ":")
+	// One letter is a drive ("C:\"); a cell range ("A1:B2") is a location
+	if !ok || len(scheme) < 2 || isCellRange(target) {
+		return false
+	}
+	for i, r := range scheme {
+		letter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if !letter && (i == 0 || !unicode.IsDigit(r) && r != '+' && r != '-' && r != '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// isCellRange reports whether ref is a reference on the current sheet: a
+// cell ("B2"), or a range of cells, columns or rows ("A1:C3", "B:D", "2:4").
+func isCellRange(ref string) bool {
+	parts := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+	if len(parts) > 2 {
+		return false
+	}
+	kind := func(part string) string {
+		if _, _, err := excelize.CellNameToCoordinates(part); err == nil {
+			return "cell"
+		}
+		if _, err := excelize.ColumnNameToNumber(part); err == nil {
+			return "column"
+		}
+		if n, err := strconv.Atoi(part); err == nil && n > 0 {
+			return "row"
+		}
+		return ""
+	}
+	if len(parts) == 1 {
+		return kind(parts[0]) == "cell"
+	}
+	return kind(parts[0]) != "" && kind(parts[0]) == kind(parts[1])
+}
+
+func hasFormatting(runs []excelize.RichTextRun) bool {
+	for _, run := range runs {
+		if run.Font != nil && (run.Font.Bold || run.Font.Italic || run.Font.Strike) {
+			return true
+		}
+	}
+	return false
+}
+
+// markdownRuns keeps the formatting of rich text runs that Markdown can show.
+func markdownRuns(runs []excelize.RichTextRun) []mdtable.Run {
+	out := make([]mdtable.Run, len(runs))
+	for i, run := range runs {
+		out[i].Text = run.Text
+		if run.Font != nil {
+			out[i].Bold, out[i].Italic, out[i].Strike = run.Font.Bold, run.Font.Italic, run.Font.Strike
+		}
+	}
+	return out
+}
+
+// sectionAnchors returns the anchor of each sheet's section heading, keyed
+// by lower-cased sheet name, for the sheets that get a section. GitHub
+// numbers repeated headings in document order, so every heading the
+// sections write counts: titles, text rows that read as headings and the
+// formulas headings.
+func sectionAnchors(contents []*rangeContent, opts ConvertOptions) map[string]string {
+	anchors := make(map[string]string)
+	seen := make(map[string]int)
+	for _, c := range contents {
+		if c.empty() {
+			continue
+		}
+		anchor := headingAnchor(c.rng.Title, seen)
+		if c.rng.Title == c.rng.Sheet {
+			anchors[strings.ToLower(c.rng.Sheet)] = anchor
+		}
+		for _, block := range c.blocks {
+			for _, text := range block.Preamble {
+				for _, line := range strings.Split(c.resolve(text, nil), "\n") {
+					if title, ok := headingText(line); ok {
+						headingAnchor(title, seen)
+					}
+				}
+			}
+		}
+		if opts.Formulas == FormulasTable && len(c.formulas) > 0 {
+			headingAnchor("Formulas", seen)
+		}
+	}
+	return anchors
+}
+
+// headingText returns the text of an ATX heading line ("## Sheet1").
+func headingText(line string) (string, bool) {
+	text := strings.TrimLeft(line, "#")
+	level := len(line) - len(text)
+	if level < 1 || level > 6 || (text != "" && text[0] != ' ') {
+		return "", false
+	}
+	return strings.TrimSpace(text), true
+}
+
+// headingAnchor returns the anchor GitHub generates for a heading: lower
+// case, punctuation removed and spaces turned into hyphens. seen records the
+// anchors handed out so far; a repeated anchor gets the next free "-1", "-2"
+// suffix, as GitHub numbers them.
+func headingAnchor(title string, seen map[string]int) string {
+	var sb strings.Builder
+	sb.WriteString("#")
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == ' ':
+			sb.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			sb.WriteRune(r)
+		}
+	}
+	base := sb.String()
+	anchor := base
+	for _, taken := seen[anchor]; taken; _, taken = seen[anchor] {
+		seen[base]++
+		anchor = base + "-" + strconv.Itoa(seen[base])
+	}
+	seen[anchor] = 0
+	return anchor
+}
@@ -0,0 +1,156 @@
+package xlsx2md
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// TableMode selects how Excel tables (ListObjects) on a sheet are converted.
+type TableMode int
+
+const (
+	// TablesIgnore converts each sheet cell by cell, as if it had no tables.
+	TablesIgnore TableMode = iota
+	// TablesDetect renders each Excel table as its own section using its
+	// declared header and totals rows, and the cells around the tables as
+	// the sheet's section.
+	TablesDetect
+	// TablesOnly renders the Excel tables and drops the cells around them.
+	// Sheets without tables are skipped.
+	TablesOnly
+)
+
+// excelTable is what an Excel table declares beyond its range.
+type excelTable struct {
+	HeaderRows int      // header rows at the top of the range; 0 when the header is hidden
+	TotalsRows int      // totals rows at the bottom of the range
+	Columns    []string // declared column names
+}
+
+// readExcelTables reads the table parts of a workbook package, keyed by
+// lower-cased table name. excelize does not expose the totals row count or
+// column names of a table, so the parts are read from the package directly.
+func readExcelTables(zr *zip.Reader) (map[string]*excelTable, error) {
+	tables := make(map[string]*excelTable)
+	for _, zf := range zr.File {
+		if !strings.HasPrefix(zf.Name, "xl/tables/") || !strings.HasSuffix(zf.Name, ".xml") {
+			continue
+		}
+		data, err := readPart(zf)
+		if err != nil {
+			return nil, err
+		}
+		var part xmlTablePart
+		if err := xml.Unmarshal(data, &part); err != nil {
+			continue // a broken table part leaves the table treated as plain cells
+		}
+		tbl := &excelTable{HeaderRows: 1, TotalsRows: part.TotalsRowCount}
+		if part.HeaderRowCount != nil {
+			tbl.HeaderRows = *part.HeaderRowCount
+		}
+		for _, col := range part.Columns {
+			tbl.Columns = append(tbl.Columns, col.Name)
+		}
+		tables[strings.ToLower(part.Name)] = tbl
+	}
+	return tables, nil
+}
+
+func readPart(zf *zip.File) ([]byte, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// apply prepares a table's cropped rows: a hidden header is replaced by the
+// declared column names, and totals rows are set in bold unless the table is
+// written as HTML. It returns the number of rows inserted at the top.
+func (t *excelTable) apply(rows [][]string, html bool) ([][]string, int) {
+	for i := max(len(rows)-t.TotalsRows, 0); i < len(rows) && !html; i++ {
+		rows[i] = slices.Clone(rows[i])
+		for j, cell := range rows[i] {
+			rows[i][j] = strongCell(cell)
+		}
+	}
+	if t.HeaderRows == 0 && len(t.Columns) > 0 {
+		return append([][]string{t.Columns}, rows...), 1
+	}
+	return rows, 0
+}
+
+// strongCell sets a cell's text in bold, keeping surrounding spaces outside
+// the delimiters so they still count as emphasis.
+func strongCell(cell string) string {
+	text := strings.TrimSpace(cell)
+	if text == "" {
+		return cell
+	}
+	lead := cell[:strings.Index(cell, text)]
+	return lead + "**" + text + "**" + cell[len(lead)+len(text):]
+}
+
+// sheetTables lists the Excel tables on a sheet as ranges.
+func sheetTables(f *excelize.File, sheet string, tables map[string]*excelTable) ([]sheetRange, error) {
+	list, err := f.GetTables(sheet)
+	if err != nil {
+		return nil, err
+	}
+	var ranges []sheetRange
+	for _, tbl := range list {
+		rng, err := parseRangeRef(f, tbl.Range, sheet)
+		if err != nil {
+			return nil, err
+		}
+		rng.Title = tbl.Name
+		rng.Table = tables[strings.ToLower(tbl.Name)]
+		ranges = append(ranges, rng)
+	}
+	return ranges, nil
+}
+
+// blankRanges clears the cells of rows that fall inside any of the ranges,
+// then drops trailing rows left empty.
+func blankRanges(rows [][]string, ranges []sheetRange) [][]string {
+	for _, rng := range ranges {
+		for i := rng.FromRow; i <= rng.ToRow && i < len(rows); i++ {
+			for j := rng.FromCol; j <= rng.ToCol && j < len(rows[i]); j++ {
+				rows[i][j] = ""
+			}
+		}
+	}
+	for len(rows) > 0 && isBlankRow(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if cell != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// --- XML structures ---
+
+type xmlTablePart struct {
+	XMLName        xml.Name         `xml:"table"`
+	Name           string           `xml:"name,attr"`
+	HeaderRowCount *int             `xml:"headerRowCount,attr"`
+	TotalsRowCount int              `xml:"totalsRowCount,attr"`
+	Columns        []xmlTableColumn `xml:"tableColumns>tableColumn"`
+}
+
+type xmlTableColumn struct {
+	Name string `xml:"name,attr"`
+}
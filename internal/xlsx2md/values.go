@@ -0,0 +1,128 @@
+package xlsx2md
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ValueMode selects how cell values are written.
+type ValueMode int
+
+const (
+	// ValuesFormatted writes values as Excel displays them, using each
+	// cell's number format.
+	ValuesFormatted ValueMode = iota
+	// ValuesRaw writes the stored values: unformatted numbers and date serials.
+	ValuesRaw
+	// ValuesISO writes raw numbers, with date and time cells normalized to
+	// ISO 8601 (2006-01-02, 15:04:05 or 2006-01-02T15:04:05).
+	ValuesISO
+)
+
+// readRows returns a sheet's cell values in the given mode.
+func readRows(f *excelize.File, sheet string, mode ValueMode) ([][]string, error) {
+	if mode == ValuesFormatted {
+		return f.GetRows(sheet)
+	}
+	rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil || mode == ValuesRaw {
+		return rows, err
+	}
+
+	date1904 := false
+	if props, err := f.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		date1904 = *props.Date1904
+	}
+	dateStyles := make(map[int]bool) // style id -> has a date/time number format
+	for i, row := range rows {
+		for j, value := range row {
+			serial, err := strconv.ParseFloat(value, 64)
+			if err != nil || serial < 0 {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
+			if err != nil {
+				return nil, err
+			}
+			styleID, err := f.GetCellStyle(sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			isDate, ok := dateStyles[styleID]
+			if !ok {
+				isDate = isDateStyle(f, styleID)
+				dateStyles[styleID] = isDate
+			}
+			if isDate {
+				row[j] = isoDateTime(serial, date1904)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// isoDateTime formats a date serial as an ISO 8601 date, time or date-time,
+// depending on whether it has a date part, a time part, or both.
+func isoDateTime(serial float64, date1904 bool) string {
+	t, err := excelize.ExcelDateToTime(serial, date1904)
+	if err != nil {
+		return strconv.FormatFloat(serial, 'f', -1, 64)
+	}
+	switch {
+	case serial < 1:
+		return t.Format(time.TimeOnly)
+	case t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0:
+		return t.Format(time.DateOnly)
+	default:
+		return t.Format("2006-01-02T15:04:05")
+	}
+}
+
+func isDateStyle(f *excelize.File, styleID int) bool {
+	style, err := f.GetStyle(styleID)
+	if err != nil || style == nil {
+		return false
+	}
+	if style.CustomNumFmt != nil {
+		return isDateFormat(*style.CustomNumFmt)
+	}
+	return isBuiltInDateFormat(style.NumFmt)
+}
+
+// isBuiltInDateFormat reports whether a built-in number format id is a date
+// or time format, including the East Asian locale formats.
+func isBuiltInDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 27 && id <= 36) ||
+		(id >= 45 && id <= 47) || (id >= 50 && id <= 58)
+}
+
+// isDateFormat reports whether a number format code formats dates or times,
+// ignoring quoted literals, escaped characters and [color]/[$-locale] tags.
+func isDateFormat(code string) bool {
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; c {
+		case '"':
+			if end := strings.IndexByte(code[i+1:], '"'); end >= 0 {
+				i += end + 1
+			}
+		case '\\', '_', '*':
+			i++ // the next character is a literal or a fill
+		case '[':
+			end := strings.IndexByte(code[i:], ']')
+			if end < 0 {
+				return false
+			}
+			// Elapsed time ([h], [mm], [ss]) is the only tag that formats a value
+			if tag := strings.ToLower(code[i+1 : i+end]); tag != "" && strings.Trim(tag, "hms") == "" {
+				return true
+			}
+			i += end
+		case 'y', 'Y', 'm', 'M', 'd', 'D', 'h', 'H', 's', 'S':
+			return true
+		}
+	}
+	return false
+}